@@ -0,0 +1,225 @@
+package bitriseio
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bitrise-io/codesigndoc/bitriseio/bitrise"
+	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-io/goinp/goinp"
+)
+
+// searchPromptAppCount is the number of apps above which a search is asked before listing the apps.
+const searchPromptAppCount = 20
+
+// AppFilter filters the apps offered by the interactive app selection.
+type AppFilter struct {
+	// Owner is the name or slug of the app's owner (user or workspace), matched case-insensitively.
+	Owner string
+	// ProjectType is the app's project type, i.e. "ios", "macos", "flutter", matched case-insensitively.
+	ProjectType string
+	// IncludeDisabled includes the disabled apps.
+	IncludeDisabled bool
+}
+
+// AppSelector selects the app to work with: by slug, by title and/or repository URL,
+// or interactively from the apps passing the filter.
+type AppSelector struct {
+	Slug    string
+	Title   string
+	RepoURL string
+	Filter  AppFilter
+}
+
+// IsSet returns true if the app is given by slug, title or repository URL.
+func (selector AppSelector) IsSet() bool {
+	return selector.Slug != "" || selector.Title != "" || selector.RepoURL != ""
+}
+
+// FilterApps returns the apps passing the filter.
+func FilterApps(apps []bitrise.Application, filter AppFilter) []bitrise.Application {
+	var filtered []bitrise.Application
+	for _, app := range apps {
+		if app.IsDisabled && !filter.IncludeDisabled {
+			continue
+		}
+		if filter.Owner != "" && !strings.EqualFold(app.Owner.Name, filter.Owner) && !strings.EqualFold(app.Owner.Slug, filter.Owner) {
+			continue
+		}
+		if filter.ProjectType != "" && !strings.EqualFold(app.ProjectType, filter.ProjectType) {
+			continue
+		}
+		filtered = append(filtered, app)
+	}
+	return filtered
+}
+
+// FindApp returns the app with the given title and/or repository URL.
+// The title is matched case-insensitively, the repository URL ignoring a trailing slash and ".git" suffix.
+// It fails if no app or more than one app matches.
+func FindApp(apps []bitrise.Application, title, repoURL string) (bitrise.Application, error) {
+	if title == "" && repoURL == "" {
+		return bitrise.Application{}, errors.New("no app title or repository URL given")
+	}
+
+	var matching []bitrise.Application
+	for _, app := range apps {
+		if title != "" && !strings.EqualFold(app.Title, title) {
+			continue
+		}
+		if repoURL != "" && normalizeRepoURL(app.RepoURL) != normalizeRepoURL(repoURL) {
+			continue
+		}
+		matching = append(matching, app)
+	}
+
+	switch len(matching) {
+	case 0:
+		return bitrise.Application{}, fmt.Errorf("no app found with %s", appQueryDescription(title, repoURL))
+	case 1:
+		return matching[0], nil
+	default:
+		var labels []string
+		for _, app := range matching {
+			labels = append(labels, fmt.Sprintf("%s (slug: %s)", appLabel(app), app.Slug))
+		}
+		return bitrise.Application{}, fmt.Errorf("%d apps found with %s, use the app slug instead:\n- %s", len(matching), appQueryDescription(title, repoURL), strings.Join(labels, "\n- "))
+	}
+}
+
+// SearchApps returns the apps matching the query: first the apps whose title, repository URL or owner
+// contains the query, then the ones including the query's characters in order (i.e. "cdoc" matches "codesigndoc").
+// The matching is case-insensitive.
+func SearchApps(apps []bitrise.Application, query string) []bitrise.Application {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return apps
+	}
+
+	var containing, fuzzyMatching []bitrise.Application
+	for _, app := range apps {
+		text := strings.ToLower(strings.Join([]string{app.Title, app.RepoURL, app.Owner.Name}, " "))
+		if strings.Contains(text, query) {
+			containing = append(containing, app)
+		} else if isSubsequence(query, text) {
+			fuzzyMatching = append(fuzzyMatching, app)
+		}
+	}
+	return append(containing, fuzzyMatching...)
+}
+
+// isSubsequence returns true if the characters of the query are in the text in the same order.
+func isSubsequence(query, text string) bool {
+	queryRunes := []rune(query)
+	i := 0
+	for _, r := range text {
+		if i < len(queryRunes) && r == queryRunes[i] {
+			i++
+		}
+	}
+	return i == len(queryRunes)
+}
+
+func normalizeRepoURL(repoURL string) string {
+	repoURL = strings.ToLower(strings.TrimSpace(repoURL))
+	repoURL = strings.TrimSuffix(repoURL, "/")
+	return strings.TrimSuffix(repoURL, ".git")
+}
+
+func appQueryDescription(title, repoURL string) string {
+	var parts []string
+	if title != "" {
+		parts = append(parts, fmt.Sprintf("title: %s", title))
+	}
+	if repoURL != "" {
+		parts = append(parts, fmt.Sprintf("repository URL: %s", repoURL))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func appLabel(app bitrise.Application) string {
+	label := app.Title + " (" + app.RepoURL + ")"
+	if app.Owner.Name != "" {
+		label += " - " + app.Owner.Name
+	}
+	if app.IsDisabled {
+		label += " [disabled]"
+	}
+	return label
+}
+
+// resolveAppSlug returns the slug of the app selected by the selector, the app is asked if it is not given.
+func resolveAppSlug(client bitrise.API, selector AppSelector) (string, error) {
+	if selector.Slug != "" {
+		return selector.Slug, nil
+	}
+
+	appList, err := client.GetAppList()
+	if err != nil {
+		return "", err
+	}
+
+	if selector.Title != "" || selector.RepoURL != "" {
+		app, err := FindApp(appList, selector.Title, selector.RepoURL)
+		if err != nil {
+			return "", err
+		}
+		log.Printf("Selected app: %s (slug: %s)", appLabel(app), app.Slug)
+		return app.Slug, nil
+	}
+
+	return selectApp(FilterApps(appList, selector.Filter))
+}
+
+func selectApp(appList []bitrise.Application) (seledtedAppSlug string, err error) {
+	if len(appList) == 0 {
+		return "", errors.New("no app found, check the app filters")
+	}
+
+	apps := appList
+	for len(apps) > searchPromptAppCount {
+		fmt.Println()
+		fmt.Printf("%d apps found, search by title, repository URL or owner (leave empty to list every app): ", len(apps))
+		query, err := goinp.AskForOptionalInput("", true)
+		if err != nil {
+			return "", fmt.Errorf("failed to read input: %s", err)
+		}
+		if query == "" {
+			break
+		}
+
+		found := SearchApps(appList, query)
+		if len(found) == 0 {
+			log.Warnf("No app matches: %s", query)
+			continue
+		}
+		apps = found
+	}
+
+	if len(apps) == 1 {
+		log.Printf("Selected app: %s", appLabel(apps[0]))
+		return apps[0].Slug, nil
+	}
+
+	var selectionList []string
+	for _, app := range apps {
+		selectionList = append(selectionList, appLabel(app))
+	}
+
+	userSelection, err := goinp.SelectFromStringsFromReaderWithDefault("Select the app which you want to upload the provisioning profiles", 1, selectionList, os.Stdin)
+	if err != nil {
+		return "", fmt.Errorf("failed to read input: %s", err)
+	}
+
+	log.Debugf("selected app: %v", userSelection)
+
+	for index, selected := range selectionList {
+		if selected == userSelection {
+			return apps[index].Slug, nil
+		}
+	}
+
+	return "", errors.New("failed to find selected app in appList")
+}
@@ -0,0 +1,62 @@
+package bitriseio
+
+import (
+	"testing"
+
+	"github.com/bitrise-io/codesigndoc/bitriseio/bitrise"
+	"github.com/stretchr/testify/require"
+)
+
+var testApps = []bitrise.Application{
+	{Slug: "slug-1", Title: "codesigndoc", RepoURL: "https://github.com/bitrise-io/codesigndoc.git", ProjectType: "macos", Owner: bitrise.Owner{Name: "Bitrise", Slug: "bitrise-workspace"}},
+	{Slug: "slug-2", Title: "Sample iOS", RepoURL: "git@github.com:bitrise-io/sample-ios.git", ProjectType: "ios", Owner: bitrise.Owner{Name: "Bitrise", Slug: "bitrise-workspace"}},
+	{Slug: "slug-3", Title: "Sample iOS", RepoURL: "git@github.com:other/sample-ios.git", ProjectType: "ios", Owner: bitrise.Owner{Name: "Other", Slug: "other-workspace"}},
+	{Slug: "slug-4", Title: "Legacy", RepoURL: "git@github.com:other/legacy.git", ProjectType: "ios", IsDisabled: true, Owner: bitrise.Owner{Name: "Other", Slug: "other-workspace"}},
+}
+
+func appSlugs(apps []bitrise.Application) []string {
+	var slugs []string
+	for _, app := range apps {
+		slugs = append(slugs, app.Slug)
+	}
+	return slugs
+}
+
+func TestFilterApps(t *testing.T) {
+	require.Equal(t, []string{"slug-1", "slug-2", "slug-3"}, appSlugs(FilterApps(testApps, AppFilter{})))
+	require.Equal(t, []string{"slug-1", "slug-2", "slug-3", "slug-4"}, appSlugs(FilterApps(testApps, AppFilter{IncludeDisabled: true})))
+	require.Equal(t, []string{"slug-3", "slug-4"}, appSlugs(FilterApps(testApps, AppFilter{Owner: "other-workspace", IncludeDisabled: true})))
+	require.Equal(t, []string{"slug-1", "slug-2"}, appSlugs(FilterApps(testApps, AppFilter{Owner: "bitrise"})))
+	require.Equal(t, []string{"slug-2", "slug-3"}, appSlugs(FilterApps(testApps, AppFilter{ProjectType: "iOS"})))
+}
+
+func TestFindApp(t *testing.T) {
+	app, err := FindApp(testApps, "CodesignDoc", "")
+	require.NoError(t, err)
+	require.Equal(t, "slug-1", app.Slug)
+
+	app, err = FindApp(testApps, "", "https://github.com/bitrise-io/codesigndoc/")
+	require.NoError(t, err)
+	require.Equal(t, "slug-1", app.Slug)
+
+	_, err = FindApp(testApps, "Sample iOS", "")
+	require.EqualError(t, err, `2 apps found with title: Sample iOS, use the app slug instead:
+- Sample iOS (git@github.com:bitrise-io/sample-ios.git) - Bitrise (slug: slug-2)
+- Sample iOS (git@github.com:other/sample-ios.git) - Other (slug: slug-3)`)
+
+	app, err = FindApp(testApps, "Sample iOS", "git@github.com:other/sample-ios.git")
+	require.NoError(t, err)
+	require.Equal(t, "slug-3", app.Slug)
+
+	_, err = FindApp(testApps, "Missing", "")
+	require.EqualError(t, err, "no app found with title: Missing")
+}
+
+func TestSearchApps(t *testing.T) {
+	require.Equal(t, []string{"slug-2", "slug-3"}, appSlugs(SearchApps(testApps, "sample")))
+	// The apps containing the query are listed before the fuzzy matches
+	require.Equal(t, []string{"slug-3", "slug-4"}, appSlugs(SearchApps(testApps, "OTHER"))[:2])
+	require.Equal(t, []string{"slug-1"}, appSlugs(SearchApps(testApps, "cdsgndc")))
+	require.Equal(t, 4, len(SearchApps(testApps, " ")))
+	require.Empty(t, SearchApps(testApps, "xyz"))
+}
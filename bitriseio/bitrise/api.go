@@ -0,0 +1,33 @@
+package bitrise
+
+import (
+	"io"
+	"math/big"
+
+	"github.com/bitrise-io/go-xcode/certificateutil"
+	"github.com/bitrise-io/go-xcode/profileutil"
+)
+
+// API is the interface of the Bitrise API operations, implemented by Client.
+// The failed requests return an *APIError, which can be checked against the Err* kinds with errors.Is.
+type API interface {
+	GetAppList() ([]Application, error)
+	SetSelectedAppSlug(slug string)
+	UploadArtifact(uploadURL string, content io.Reader) error
+
+	FetchUploadedIdentities() ([]IdentityListData, error)
+	DownloadIdentity(identitySlug string) ([]byte, string, error)
+	GetUploadedCertificates(identitySlug string) ([]certificateutil.CertificateInfoModel, error)
+	GetUploadedCertificatesSerialby(identitySlug string) ([]big.Int, error)
+	RegisterIdentity(certificateSize int64, certificatePassword string, isProtected, isExpose bool) (RegisterIdentityData, error)
+	ConfirmIdentityUpload(certificateSlug string, certificateUploadName string) (ConfirmIdentityUploadData, error)
+
+	FetchProvisioningProfiles() ([]ProvisioningProfileListData, error)
+	DownloadProvisioningProfile(profileSlug string) ([]byte, error)
+	GetUploadedProvisioningProfile(profileSlug string) (profileutil.ProvisioningProfileInfoModel, error)
+	GetUploadedProvisioningProfileUUIDby(profileSlug string) (string, error)
+	RegisterProvisioningProfile(provisioningProfSize int64, exportedProfileName string, isProtected, isExpose bool) (RegisterProvisioningProfileData, error)
+	ConfirmProvisioningProfileUpload(profileSlug string, provUploadName string) (ConfirmProvProfileUploadData, error)
+}
+
+var _ API = (*Client)(nil)
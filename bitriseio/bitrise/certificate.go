@@ -33,7 +33,7 @@ type ConfirmIdentityUploadData struct {
 	Processed           bool   `json:"processed"`
 	CertificatePassword string `json:"certificate_password"`
 	IsExpose            bool   `json:"is_expose"`
-	IsProtected         bool   `json:"dais_protectedta"`
+	IsProtected         bool   `json:"is_protected"`
 }
 
 // ConfirmIdentityUploadResponse ...
@@ -49,12 +49,17 @@ type IdentityListData struct {
 	Processed           bool   `json:"processed"`
 	CertificatePassword string `json:"certificate_password"`
 	IsExpose            bool   `json:"is_expose"`
-	IsProtected         bool   `json:"dais_protectedta"`
+	IsProtected         bool   `json:"is_protected"`
 }
 
 // IdentityListResponse ...
 type IdentityListResponse struct {
-	Data []IdentityListData `json:"data"`
+	Data   []IdentityListData `json:"data"`
+	Paging Paging             `json:"paging"`
+}
+
+func (response IdentityListResponse) paging() Paging {
+	return response.Paging
 }
 
 // IdentityData ...
@@ -65,7 +70,7 @@ type IdentityData struct {
 	Processed           bool   `json:"processed"`
 	CertificatePassword string `json:"certificate_password"`
 	IsExpose            bool   `json:"is_expose"`
-	IsProtected         bool   `json:"dais_protectedta"`
+	IsProtected         bool   `json:"is_protected"`
 	DownloadURL         string `json:"download_url"`
 }
 
@@ -74,47 +79,50 @@ type IdentityResponse struct {
 	Data IdentityData `json:"data"`
 }
 
-// FetchUploadedIdentities ...
+// FetchUploadedIdentities returns the uploaded identities of the selected app, from every page.
 func (client *Client) FetchUploadedIdentities() ([]IdentityListData, error) {
-	log.Debugf("\nDownloading provisioning profile list from Bitrise...")
-
-	requestURL, err := urlutil.Join(baseURL, appsEndPoint, client.selectedAppSlug, certificatesEndPoint)
-	if err != nil {
-		return []IdentityListData{}, err
-	}
+	log.Debugf("\nDownloading identity list from Bitrise...")
 
-	request, err := createRequest(http.MethodGet, requestURL, client.headers, nil)
-	if err != nil {
-		return []IdentityListData{}, err
+	var identities []IdentityListData
+	iterator := client.Identities()
+	for iterator.Next() {
+		identities = append(identities, iterator.Page()...)
 	}
-	log.Debugf("\nRequest URL: %s", requestURL)
-
-	// Response struct
-	var requestResponse IdentityListResponse
-	//
-	// Perform request
-	response, _, err := RunRequest(client, request, &requestResponse)
-	if err != nil {
+	if err := iterator.Err(); err != nil {
 		return nil, err
 	}
 
-	requestResponse = *response.(*IdentityListResponse)
-	return requestResponse.Data, nil
+	return identities, nil
 }
 
-// GetUploadedCertificatesSerialby ...
-func (client *Client) GetUploadedCertificatesSerialby(identitySlug string) (certificateSerialList []big.Int, err error) {
+// DownloadIdentity downloads the uploaded identity (.p12), returns its content and passphrase.
+func (client *Client) DownloadIdentity(identitySlug string) ([]byte, string, error) {
 	downloadURL, certificatePassword, err := client.getUploadedIdentityDownloadURLBy(identitySlug)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	content, err := client.downloadUploadedIdentity(downloadURL)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return []byte(content), certificatePassword, nil
+}
+
+// GetUploadedCertificates downloads the uploaded identity (.p12) and returns its certificates.
+func (client *Client) GetUploadedCertificates(identitySlug string) ([]certificateutil.CertificateInfoModel, error) {
+	content, certificatePassword, err := client.DownloadIdentity(identitySlug)
 	if err != nil {
 		return nil, err
 	}
 
-	certificates, err := certificateutil.CertificatesFromPKCS12Content([]byte(content), certificatePassword)
+	return certificateutil.CertificatesFromPKCS12Content(content, certificatePassword)
+}
+
+// GetUploadedCertificatesSerialby ...
+func (client *Client) GetUploadedCertificatesSerialby(identitySlug string) (certificateSerialList []big.Int, err error) {
+	certificates, err := client.GetUploadedCertificates(identitySlug)
 	if err != nil {
 		return nil, err
 	}
@@ -139,7 +147,7 @@ func (client *Client) GetUploadedCertificatesSerialby(identitySlug string) (cert
 func (client *Client) getUploadedIdentityDownloadURLBy(certificateSlug string) (downloadURL string, password string, err error) {
 	log.Debugf("\nGet downloadURL for certificate (slug - %s) from Bitrise...", certificateSlug)
 
-	requestURL, err := urlutil.Join(baseURL, appsEndPoint, client.selectedAppSlug, certificatesEndPoint, certificateSlug)
+	requestURL, err := urlutil.Join(client.baseURL, appsEndPoint, client.selectedAppSlug, certificatesEndPoint, certificateSlug)
 	if err != nil {
 		return "", "", err
 	}
@@ -189,11 +197,12 @@ func (client *Client) downloadUploadedIdentity(downloadURL string) (content stri
 
 }
 
-// RegisterIdentity ...
-func (client *Client) RegisterIdentity(certificateSize int64) (RegisterIdentityData, error) {
+// RegisterIdentity registers the Identities.p12 file with its passphrase,
+// and whether it is protected and exposed to pull request builds.
+func (client *Client) RegisterIdentity(certificateSize int64, certificatePassword string, isProtected, isExpose bool) (RegisterIdentityData, error) {
 	log.Printf("Register %s on Bitrise...", "Identities.p12")
 
-	requestURL, err := urlutil.Join(baseURL, appsEndPoint, client.selectedAppSlug, certificatesEndPoint)
+	requestURL, err := urlutil.Join(client.baseURL, appsEndPoint, client.selectedAppSlug, certificatesEndPoint)
 	if err != nil {
 		return RegisterIdentityData{}, err
 	}
@@ -201,8 +210,11 @@ func (client *Client) RegisterIdentity(certificateSize int64) (RegisterIdentityD
 	log.Debugf("\nRequest URL: %s", requestURL)
 
 	fields := map[string]interface{}{
-		"upload_file_name": "Identities.p12",
-		"upload_file_size": certificateSize,
+		"upload_file_name":     "Identities.p12",
+		"upload_file_size":     certificateSize,
+		"certificate_password": certificatePassword,
+		"is_protected":         isProtected,
+		"is_expose":            isExpose,
 	}
 
 	request, err := createRequest(http.MethodPost, requestURL, client.headers, fields)
@@ -224,26 +236,28 @@ func (client *Client) RegisterIdentity(certificateSize int64) (RegisterIdentityD
 	return requestResponse.Data, nil
 }
 
-// ConfirmIdentityUpload ...
-func (client *Client) ConfirmIdentityUpload(certificateSlug string, certificateUploadName string) error {
+// ConfirmIdentityUpload confirms the upload of the identities, and returns the attributes of the uploaded file.
+func (client *Client) ConfirmIdentityUpload(certificateSlug string, certificateUploadName string) (ConfirmIdentityUploadData, error) {
 	log.Printf("Confirm - %s - upload to Bitrise...", certificateUploadName)
 
-	requestURL, err := urlutil.Join(baseURL, appsEndPoint, client.selectedAppSlug, "build-certificates", certificateSlug, "uploaded")
+	requestURL, err := urlutil.Join(client.baseURL, appsEndPoint, client.selectedAppSlug, "build-certificates", certificateSlug, "uploaded")
 	if err != nil {
-		return err
+		return ConfirmIdentityUploadData{}, err
 	}
 
 	request, err := createRequest(http.MethodPost, requestURL, client.headers, nil)
 	if err != nil {
-		return err
+		return ConfirmIdentityUploadData{}, err
 	}
 
 	// Response struct
-	requestResponse := ConfirmProvProfileUploadResponse{}
+	requestResponse := ConfirmIdentityUploadResponse{}
 
-	_, _, err = RunRequest(client, request, &requestResponse)
+	response, _, err := RunRequest(client, request, &requestResponse)
 	if err != nil {
-		return err
+		return ConfirmIdentityUploadData{}, err
 	}
-	return nil
+
+	requestResponse = *response.(*ConfirmIdentityUploadResponse)
+	return requestResponse.Data, nil
 }
@@ -8,16 +8,14 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
-	"strings"
 	"time"
 
 	"github.com/bitrise-io/go-utils/log"
 	"github.com/bitrise-io/go-utils/retry"
-	"github.com/bitrise-io/go-utils/urlutil"
 )
 
 const (
-	baseURL                      = "https://api.bitrise.io/v0.1/"
+	defaultBaseURL               = "https://api.bitrise.io/v0.1/"
 	appsEndPoint                 = "/apps"
 	provisioningProfilesEndPoint = "/provisioning-profiles"
 	certificatesEndPoint         = "/build-certificates"
@@ -58,8 +56,13 @@ type MyAppsResponse struct {
 	Paging Paging        `json:"paging"`
 }
 
+func (response MyAppsResponse) paging() Paging {
+	return response.Paging
+}
+
 // Client ...
 type Client struct {
+	baseURL         string
 	accessToken     string
 	selectedAppSlug string
 	headers         map[string]string
@@ -68,57 +71,26 @@ type Client struct {
 
 // NewClient ...
 func NewClient(accessToken string) (*Client, error) {
-	client := &Client{accessToken, "", map[string]string{"Authorization": "token " + accessToken}, http.Client{}}
+	client := &Client{
+		baseURL:     defaultBaseURL,
+		accessToken: accessToken,
+		headers:     map[string]string{"Authorization": "token " + accessToken},
+		client:      http.Client{},
+	}
 	return client, nil
 }
 
 // GetAppList returns the list of apps for the given access token
 func (client *Client) GetAppList() ([]Application, error) {
-	var apps []Application
-
 	log.Infof("Fetching your application list from Bitrise...")
 
-	requestURL, err := urlutil.Join(baseURL, appsEndPoint)
-	if err != nil {
-		return nil, err
+	var apps []Application
+	iterator := client.Apps()
+	for iterator.Next() {
+		apps = append(apps, iterator.Page()...)
 	}
-
-	log.Debugf("\nRequest URL: %s", requestURL)
-
-	// Response struct
-	var appListResponse MyAppsResponse
-	stillPaging := true
-	var next string
-
-	for stillPaging {
-		headers := client.headers
-
-		request, err := createRequest(http.MethodGet, requestURL, headers, nil)
-		if err != nil {
-			return nil, err
-		}
-
-		if len(next) > 0 {
-			quearryValues := request.URL.Query()
-			quearryValues.Add("next", next)
-			request.URL.RawQuery = quearryValues.Encode()
-		}
-
-		// Perform request
-		response, _, err := RunRequest(client, request, &appListResponse)
-		if err != nil {
-			return nil, err
-		}
-
-		appListResponse = *response.(*MyAppsResponse)
-		apps = append(apps, appListResponse.Data...)
-
-		if len(appListResponse.Paging.Next) > 0 {
-			next = appListResponse.Paging.Next
-			appListResponse = MyAppsResponse{}
-		} else {
-			stillPaging = false
-		}
+	if err := iterator.Err(); err != nil {
+		return nil, err
 	}
 
 	return apps, nil
@@ -148,28 +120,28 @@ func (client *Client) UploadArtifact(uploadURL string, content io.Reader) error
 func RunRequest(client *Client, req *http.Request, requestResponse interface{}) (interface{}, []byte, error) {
 	var responseBody []byte
 
-	if err := retry.Times(1).Wait(5 * time.Second).Try(func(attempt uint) error {
-		body, statusCode, err := performRequest(client, req)
+	if err := retry.Times(1).Wait(5 * time.Second).TryWithAbort(func(attempt uint) (error, bool) {
+		body, err := performRequest(client, req)
 		if err != nil {
 			log.Warnf("Attempt (%d) failed, error: %s", attempt+1, err)
-			if !strings.Contains(err.Error(), "failed to perform request") {
-				log.Warnf("Response status: %d", statusCode)
-				log.Warnf("Body: %s", string(body))
-			}
-			return err
+			log.Debugf("Body: %s", string(body))
+
+			// The client errors (i.e. an invalid token) are not retried
+			var apiErr *APIError
+			return err, errors.As(err, &apiErr) && !apiErr.isRetryable()
 		}
 
 		// Parse JSON body
 		if requestResponse != nil {
 			if err := json.Unmarshal([]byte(body), &requestResponse); err != nil {
-				return fmt.Errorf("failed to unmarshal response (%s), error: %s", body, err)
+				return fmt.Errorf("failed to unmarshal response (%s), error: %s", body, err), false
 			}
 
 			logDebugPretty(&requestResponse)
 		}
 		responseBody = body
 
-		return nil
+		return nil, false
 	}); err != nil {
 		return nil, nil, err
 	}
@@ -198,11 +170,12 @@ func createRequest(requestMethod string, url string, headers map[string]string,
 	return req, nil
 }
 
-func performRequest(bitriseClient *Client, request *http.Request) (body []byte, statusCode int, err error) {
+// performRequest returns an *APIError if the request failed with a non success status code.
+func performRequest(bitriseClient *Client, request *http.Request) (body []byte, err error) {
 	response, err := bitriseClient.client.Do(request)
 	if err != nil {
 		// On error, any Response can be ignored
-		return nil, -1, fmt.Errorf("failed to perform request, error: %s", err)
+		return nil, fmt.Errorf("failed to perform request, error: %s", err)
 	}
 
 	// The client must close the response body when finished with it
@@ -214,14 +187,14 @@ func performRequest(bitriseClient *Client, request *http.Request) (body []byte,
 
 	body, err = ioutil.ReadAll(response.Body)
 	if err != nil {
-		return []byte{}, response.StatusCode, fmt.Errorf("failed to read response body, error: %s", err)
+		return []byte{}, fmt.Errorf("failed to read response body, error: %s", err)
 	}
 
 	if response.StatusCode < http.StatusOK || response.StatusCode > http.StatusMultipleChoices {
-		return body, response.StatusCode, errors.New("non success status code")
+		return body, newAPIError(response.StatusCode, body)
 	}
 
-	return body, response.StatusCode, nil
+	return body, nil
 }
 
 func addHeaders(req *http.Request, headers map[string]string) {
@@ -0,0 +1,78 @@
+package bitrise
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// The kinds of the Bitrise API errors, an APIError can be checked against them with errors.Is.
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrValidation   = errors.New("validation error")
+)
+
+// APIError is returned if the Bitrise API responds with a non success status code.
+type APIError struct {
+	StatusCode int
+	// Message is the error message of the API's response, if any.
+	Message string
+}
+
+// errorResponse is the error response model of the Bitrise API.
+type errorResponse struct {
+	Message  string `json:"message"`
+	ErrorMsg string `json:"error_msg"`
+}
+
+func newAPIError(statusCode int, body []byte) *APIError {
+	var response errorResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return &APIError{StatusCode: statusCode}
+	}
+
+	message := response.Message
+	if message == "" {
+		message = response.ErrorMsg
+	}
+	return &APIError{StatusCode: statusCode, Message: message}
+}
+
+func (apiErr *APIError) Error() string {
+	kind := "non success status code"
+	if err := apiErr.Unwrap(); err != nil {
+		kind = err.Error()
+	}
+
+	if apiErr.Message == "" {
+		return fmt.Sprintf("%s (%d)", kind, apiErr.StatusCode)
+	}
+	return fmt.Sprintf("%s (%d): %s", kind, apiErr.StatusCode, apiErr.Message)
+}
+
+// Unwrap returns the kind of the error by the status code, or nil for the not specifically handled status codes.
+func (apiErr *APIError) Unwrap() error {
+	switch apiErr.StatusCode {
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return ErrValidation
+	default:
+		return nil
+	}
+}
+
+// isRetryable returns true if the request failed with a server error or it was rate limited.
+func (apiErr *APIError) isRetryable() bool {
+	return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= http.StatusInternalServerError
+}
@@ -0,0 +1,41 @@
+package bitrise
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAPIError(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		wantKind   error
+		wantError  string
+	}{
+		{name: "unauthorized", statusCode: http.StatusUnauthorized, body: `{"message":"Unauthorized"}`, wantKind: ErrUnauthorized, wantError: "unauthorized (401): Unauthorized"},
+		{name: "forbidden", statusCode: http.StatusForbidden, body: ``, wantKind: ErrForbidden, wantError: "forbidden (403)"},
+		{name: "not found", statusCode: http.StatusNotFound, body: `{"message":"Not Found"}`, wantKind: ErrNotFound, wantError: "not found (404): Not Found"},
+		{name: "rate limited", statusCode: http.StatusTooManyRequests, body: `{"message":"Too many requests"}`, wantKind: ErrRateLimited, wantError: "rate limited (429): Too many requests"},
+		{name: "validation error", statusCode: http.StatusUnprocessableEntity, body: `{"error_msg":"upload_file_size is required"}`, wantKind: ErrValidation, wantError: "validation error (422): upload_file_size is required"},
+		{name: "server error", statusCode: http.StatusInternalServerError, body: `<html></html>`, wantKind: nil, wantError: "non success status code (500)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := fmt.Errorf("failed to fetch: %w", newAPIError(tt.statusCode, []byte(tt.body)))
+
+			var apiErr *APIError
+			require.True(t, errors.As(err, &apiErr))
+			require.Equal(t, tt.statusCode, apiErr.StatusCode)
+			require.Equal(t, tt.wantError, apiErr.Error())
+			if tt.wantKind != nil {
+				require.True(t, errors.Is(err, tt.wantKind))
+			}
+			require.Equal(t, tt.wantKind, apiErr.Unwrap())
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package bitrise
+
+import (
+	"net/http"
+
+	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-io/go-utils/urlutil"
+)
+
+// pagedResponse is a response of a list endpoint, which returns the items page by page.
+type pagedResponse interface {
+	paging() Paging
+}
+
+// pager fetches the pages of a list endpoint one by one, following the paging's next cursor.
+type pager struct {
+	client     *Client
+	requestURL string
+	err        error
+	next       string
+	done       bool
+}
+
+func newPager(client *Client, endpoint ...string) pager {
+	requestURL, err := urlutil.Join(append([]string{client.baseURL}, endpoint...)...)
+	return pager{client: client, requestURL: requestURL, err: err}
+}
+
+// fetch fetches the next page into the response, returns false if there are no more pages or the request failed.
+func (p *pager) fetch(response pagedResponse) bool {
+	if p.done || p.err != nil {
+		return false
+	}
+
+	request, err := createRequest(http.MethodGet, p.requestURL, p.client.headers, nil)
+	if err != nil {
+		p.err = err
+		return false
+	}
+
+	if p.next != "" {
+		queryValues := request.URL.Query()
+		queryValues.Set("next", p.next)
+		request.URL.RawQuery = queryValues.Encode()
+	}
+
+	log.Debugf("\nRequest URL: %s", request.URL)
+
+	if _, _, err := RunRequest(p.client, request, response); err != nil {
+		p.err = err
+		return false
+	}
+
+	// The next cursor of the last page is empty, a repeated cursor would never end the paging.
+	next := response.paging().Next
+	p.done = next == "" || next == p.next
+	p.next = next
+
+	return true
+}
+
+// AppIterator iterates over the apps of the access token's user, page by page.
+type AppIterator struct {
+	pager pager
+	page  []Application
+}
+
+// Apps returns an iterator over the apps of the access token's user.
+func (client *Client) Apps() *AppIterator {
+	return &AppIterator{pager: newPager(client, appsEndPoint)}
+}
+
+// Next fetches the next page, returns false if there are no more pages or the request failed.
+func (iterator *AppIterator) Next() bool {
+	var response MyAppsResponse
+	if !iterator.pager.fetch(&response) {
+		return false
+	}
+	iterator.page = response.Data
+	return true
+}
+
+// Page returns the apps of the page fetched by the last call to Next.
+func (iterator *AppIterator) Page() []Application {
+	return iterator.page
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (iterator *AppIterator) Err() error {
+	return iterator.pager.err
+}
+
+// IdentityIterator iterates over the uploaded identities of the selected app, page by page.
+type IdentityIterator struct {
+	pager pager
+	page  []IdentityListData
+}
+
+// Identities returns an iterator over the uploaded identities of the selected app.
+func (client *Client) Identities() *IdentityIterator {
+	return &IdentityIterator{pager: newPager(client, appsEndPoint, client.selectedAppSlug, certificatesEndPoint)}
+}
+
+// Next fetches the next page, returns false if there are no more pages or the request failed.
+func (iterator *IdentityIterator) Next() bool {
+	var response IdentityListResponse
+	if !iterator.pager.fetch(&response) {
+		return false
+	}
+	iterator.page = response.Data
+	return true
+}
+
+// Page returns the identities of the page fetched by the last call to Next.
+func (iterator *IdentityIterator) Page() []IdentityListData {
+	return iterator.page
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (iterator *IdentityIterator) Err() error {
+	return iterator.pager.err
+}
+
+// ProvisioningProfileIterator iterates over the uploaded provisioning profiles of the selected app, page by page.
+type ProvisioningProfileIterator struct {
+	pager pager
+	page  []ProvisioningProfileListData
+}
+
+// ProvisioningProfiles returns an iterator over the uploaded provisioning profiles of the selected app.
+func (client *Client) ProvisioningProfiles() *ProvisioningProfileIterator {
+	return &ProvisioningProfileIterator{pager: newPager(client, appsEndPoint, client.selectedAppSlug, provisioningProfilesEndPoint)}
+}
+
+// Next fetches the next page, returns false if there are no more pages or the request failed.
+func (iterator *ProvisioningProfileIterator) Next() bool {
+	var response ProvisioningProfileListResponse
+	if !iterator.pager.fetch(&response) {
+		return false
+	}
+	iterator.page = response.Data
+	return true
+}
+
+// Page returns the provisioning profiles of the page fetched by the last call to Next.
+func (iterator *ProvisioningProfileIterator) Page() []ProvisioningProfileListData {
+	return iterator.page
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (iterator *ProvisioningProfileIterator) Err() error {
+	return iterator.pager.err
+}
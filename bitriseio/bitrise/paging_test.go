@@ -0,0 +1,117 @@
+package bitrise
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newPagingTestServer serves the given pages of a list endpoint, the page is selected by the next cursor,
+// and every page except the last one links the next one.
+func newPagingTestServer(t *testing.T, endpoint string, pages [][]map[string]interface{}) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc(endpoint, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "token test-token", r.Header.Get("Authorization"))
+
+		index := 0
+		if next := r.URL.Query().Get("next"); next != "" {
+			_, err := fmt.Sscanf(next, "page-%d", &index)
+			require.NoError(t, err)
+		}
+
+		paging := map[string]interface{}{"total_item_count": 0, "page_item_limit": len(pages[index])}
+		for _, page := range pages {
+			paging["total_item_count"] = paging["total_item_count"].(int) + len(page)
+		}
+		if index+1 < len(pages) {
+			paging["next"] = fmt.Sprintf("page-%d", index+1)
+		}
+
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{"data": pages[index], "paging": paging}))
+	})
+	return httptest.NewServer(mux)
+}
+
+func newTestClient(baseURL string) *Client {
+	client, _ := NewClient("test-token")
+	client.baseURL = baseURL
+	client.SetSelectedAppSlug("app-slug")
+	return client
+}
+
+func TestFetchProvisioningProfiles_Paging(t *testing.T) {
+	server := newPagingTestServer(t, "/apps/app-slug/provisioning-profiles", [][]map[string]interface{}{
+		{{"slug": "profile-1", "upload_file_name": "Development.mobileprovision", "is_protected": true}, {"slug": "profile-2"}},
+		{{"slug": "profile-3"}, {"slug": "profile-4"}},
+		{{"slug": "profile-5", "is_expose": true}},
+	})
+	defer server.Close()
+
+	profiles, err := newTestClient(server.URL).FetchProvisioningProfiles()
+	require.NoError(t, err)
+
+	var slugs []string
+	for _, profile := range profiles {
+		slugs = append(slugs, profile.Slug)
+	}
+	require.Equal(t, []string{"profile-1", "profile-2", "profile-3", "profile-4", "profile-5"}, slugs)
+	require.True(t, profiles[0].IsProtected)
+	require.Equal(t, "Development.mobileprovision", profiles[0].UploadFileName)
+	require.True(t, profiles[4].IsExpose)
+}
+
+func TestFetchUploadedIdentities_Paging(t *testing.T) {
+	server := newPagingTestServer(t, "/apps/app-slug/build-certificates", [][]map[string]interface{}{
+		{{"slug": "identity-1", "certificate_password": "secret"}},
+		{{"slug": "identity-2"}},
+	})
+	defer server.Close()
+
+	identities, err := newTestClient(server.URL).FetchUploadedIdentities()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(identities))
+	require.Equal(t, "identity-1", identities[0].Slug)
+	require.Equal(t, "secret", identities[0].CertificatePassword)
+	require.Equal(t, "identity-2", identities[1].Slug)
+}
+
+func TestAppIterator(t *testing.T) {
+	server := newPagingTestServer(t, "/apps", [][]map[string]interface{}{
+		{{"slug": "app-1", "title": "First"}, {"slug": "app-2", "title": "Second"}},
+		{{"slug": "app-3", "title": "Third"}},
+	})
+	defer server.Close()
+
+	iterator := newTestClient(server.URL).Apps()
+
+	var pages [][]string
+	for iterator.Next() {
+		var slugs []string
+		for _, app := range iterator.Page() {
+			slugs = append(slugs, app.Slug)
+		}
+		pages = append(pages, slugs)
+	}
+	require.NoError(t, iterator.Err())
+	require.Equal(t, [][]string{{"app-1", "app-2"}, {"app-3"}}, pages)
+	require.False(t, iterator.Next())
+}
+
+func TestIdentityIterator_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, err := w.Write([]byte(`{"message":"Unauthorized"}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	iterator := newTestClient(server.URL).Identities()
+	require.False(t, iterator.Next())
+	require.True(t, errors.Is(iterator.Err(), ErrUnauthorized))
+	require.Nil(t, iterator.Page())
+}
@@ -31,7 +31,7 @@ type ConfirmProvProfileUploadData struct {
 	Slug           string `json:"slug"`
 	Processed      bool   `json:"processed"`
 	IsExpose       bool   `json:"is_expose"`
-	IsProtected    bool   `json:"dais_protectedta"`
+	IsProtected    bool   `json:"is_protected"`
 }
 
 // ConfirmProvProfileUploadResponse ...
@@ -46,12 +46,17 @@ type ProvisioningProfileListData struct {
 	Slug           string `json:"slug"`
 	Processed      bool   `json:"processed"`
 	IsExpose       bool   `json:"is_expose"`
-	IsProtected    bool   `json:"dais_protectedta"`
+	IsProtected    bool   `json:"is_protected"`
 }
 
 // ProvisioningProfileListResponse ...
 type ProvisioningProfileListResponse struct {
-	Data []ProvisioningProfileListData `json:"data"`
+	Data   []ProvisioningProfileListData `json:"data"`
+	Paging Paging                        `json:"paging"`
+}
+
+func (response ProvisioningProfileListResponse) paging() Paging {
+	return response.Paging
 }
 
 // UploadedProvisioningProfileData ...
@@ -61,7 +66,7 @@ type UploadedProvisioningProfileData struct {
 	Slug           string `json:"slug"`
 	Processed      bool   `json:"processed"`
 	IsExpose       bool   `json:"is_expose"`
-	IsProtected    bool   `json:"dais_protectedta"`
+	IsProtected    bool   `json:"is_protected"`
 	DownloadURL    string `json:"download_url"`
 }
 
@@ -70,54 +75,55 @@ type UploadedProvisioningProfileResponse struct {
 	Data UploadedProvisioningProfileData `json:"data"`
 }
 
-// FetchProvisioningProfiles ...
+// FetchProvisioningProfiles returns the uploaded provisioning profiles of the selected app, from every page.
 func (client *Client) FetchProvisioningProfiles() ([]ProvisioningProfileListData, error) {
 	log.Debugf("\nDownloading provisioning profile list from Bitrise...")
 
-	requestURL, err := urlutil.Join(baseURL, appsEndPoint, client.selectedAppSlug, provisioningProfilesEndPoint)
-	if err != nil {
+	var profiles []ProvisioningProfileListData
+	iterator := client.ProvisioningProfiles()
+	for iterator.Next() {
+		profiles = append(profiles, iterator.Page()...)
+	}
+	if err := iterator.Err(); err != nil {
 		return nil, err
 	}
 
-	log.Debugf("\nRequest URL: %s", requestURL)
+	return profiles, nil
+}
 
-	request, err := createRequest(http.MethodGet, requestURL, client.headers, nil)
+// DownloadProvisioningProfile downloads the uploaded provisioning profile, returns its content.
+func (client *Client) DownloadProvisioningProfile(profileSlug string) ([]byte, error) {
+	downloadURL, err := client.getUploadedProvisioningProfileDownloadURLBy(profileSlug)
 	if err != nil {
 		return nil, err
 	}
 
-	// Response struct
-	var requestResponse ProvisioningProfileListResponse
-
-	//
-	// Perform request
-	response, _, err := RunRequest(client, request, &requestResponse)
+	content, err := client.downloadUploadedProvisioningProfile(downloadURL)
 	if err != nil {
 		return nil, err
 	}
 
-	requestResponse = *response.(*ProvisioningProfileListResponse)
-	return requestResponse.Data, nil
+	return []byte(content), nil
 }
 
-// GetUploadedProvisioningProfileUUIDby ...
-func (client *Client) GetUploadedProvisioningProfileUUIDby(profileSlug string) (UUID string, err error) {
-	downloadURL, err := client.getUploadedProvisioningProfileDownloadURLBy(profileSlug)
+// GetUploadedProvisioningProfile downloads and parses the uploaded provisioning profile.
+func (client *Client) GetUploadedProvisioningProfile(profileSlug string) (profileutil.ProvisioningProfileInfoModel, error) {
+	content, err := client.DownloadProvisioningProfile(profileSlug)
 	if err != nil {
-		return "", err
+		return profileutil.ProvisioningProfileInfoModel{}, err
 	}
 
-	content, err := client.downloadUploadedProvisioningProfile(downloadURL)
+	plistData, err := profileutil.ProvisioningProfileFromContent(content)
 	if err != nil {
-		return "", err
+		return profileutil.ProvisioningProfileInfoModel{}, err
 	}
 
-	plistData, err := profileutil.ProvisioningProfileFromContent([]byte(content))
-	if err != nil {
-		return "", err
-	}
+	return profileutil.NewProvisioningProfileInfo(*plistData)
+}
 
-	data, err := profileutil.NewProvisioningProfileInfo(*plistData)
+// GetUploadedProvisioningProfileUUIDby ...
+func (client *Client) GetUploadedProvisioningProfileUUIDby(profileSlug string) (UUID string, err error) {
+	data, err := client.GetUploadedProvisioningProfile(profileSlug)
 	if err != nil {
 		return "", err
 	}
@@ -128,7 +134,7 @@ func (client *Client) GetUploadedProvisioningProfileUUIDby(profileSlug string) (
 func (client *Client) getUploadedProvisioningProfileDownloadURLBy(profileSlug string) (downloadURL string, err error) {
 	log.Debugf("\nGet downloadURL for provisioning profile (slug - %s) from Bitrise...", profileSlug)
 
-	requestURL, err := urlutil.Join(baseURL, appsEndPoint, client.selectedAppSlug, provisioningProfilesEndPoint, profileSlug)
+	requestURL, err := urlutil.Join(client.baseURL, appsEndPoint, client.selectedAppSlug, provisioningProfilesEndPoint, profileSlug)
 	if err != nil {
 		return "", err
 	}
@@ -178,11 +184,12 @@ func (client *Client) downloadUploadedProvisioningProfile(downloadURL string) (c
 
 }
 
-// RegisterProvisioningProfile ...
-func (client *Client) RegisterProvisioningProfile(provisioningProfSize int64, exportedProfileName string) (RegisterProvisioningProfileData, error) {
+// RegisterProvisioningProfile registers a provisioning profile file,
+// and whether it is protected and exposed to pull request builds.
+func (client *Client) RegisterProvisioningProfile(provisioningProfSize int64, exportedProfileName string, isProtected, isExpose bool) (RegisterProvisioningProfileData, error) {
 	log.Printf("Register %s on Bitrise...", exportedProfileName)
 
-	requestURL, err := urlutil.Join(baseURL, appsEndPoint, client.selectedAppSlug, provisioningProfilesEndPoint)
+	requestURL, err := urlutil.Join(client.baseURL, appsEndPoint, client.selectedAppSlug, provisioningProfilesEndPoint)
 	if err != nil {
 		return RegisterProvisioningProfileData{}, err
 	}
@@ -192,6 +199,8 @@ func (client *Client) RegisterProvisioningProfile(provisioningProfSize int64, ex
 	fields := map[string]interface{}{
 		"upload_file_name": exportedProfileName,
 		"upload_file_size": provisioningProfSize,
+		"is_protected":     isProtected,
+		"is_expose":        isExpose,
 	}
 
 	request, err := createRequest(http.MethodPost, requestURL, client.headers, fields)
@@ -213,18 +222,18 @@ func (client *Client) RegisterProvisioningProfile(provisioningProfSize int64, ex
 	return requestResponse.Data, nil
 }
 
-// ConfirmProvisioningProfileUpload ...
-func (client *Client) ConfirmProvisioningProfileUpload(profileSlug string, provUploadName string) error {
+// ConfirmProvisioningProfileUpload confirms the upload of a provisioning profile, and returns the attributes of the uploaded file.
+func (client *Client) ConfirmProvisioningProfileUpload(profileSlug string, provUploadName string) (ConfirmProvProfileUploadData, error) {
 	log.Printf("Confirm - %s - upload to Bitrise...", provUploadName)
 
-	requestURL, err := urlutil.Join(baseURL, appsEndPoint, client.selectedAppSlug, provisioningProfilesEndPoint, profileSlug, "uploaded")
+	requestURL, err := urlutil.Join(client.baseURL, appsEndPoint, client.selectedAppSlug, provisioningProfilesEndPoint, profileSlug, "uploaded")
 	if err != nil {
-		return err
+		return ConfirmProvProfileUploadData{}, err
 	}
 
 	request, err := createRequest("POST", requestURL, client.headers, nil)
 	if err != nil {
-		return err
+		return ConfirmProvProfileUploadData{}, err
 	}
 
 	// Response struct
@@ -234,9 +243,9 @@ func (client *Client) ConfirmProvisioningProfileUpload(profileSlug string, provU
 	// Perform request
 	response, _, err := RunRequest(client, request, &requestResponse)
 	if err != nil {
-		return err
+		return ConfirmProvProfileUploadData{}, err
 	}
 
 	requestResponse = *response.(*ConfirmProvProfileUploadResponse)
-	return nil
+	return requestResponse.Data, nil
 }
@@ -11,29 +11,38 @@ import (
 	"github.com/bitrise-io/codesigndoc/utility"
 	"github.com/bitrise-io/go-utils/colorstring"
 	"github.com/bitrise-io/go-utils/log"
-	"github.com/bitrise-io/go-utils/sliceutil"
 	"github.com/bitrise-io/go-xcode/certificateutil"
 	"github.com/bitrise-io/goinp/goinp"
 )
 
-// GetInteractiveConfigClient asks for access token and app, returns a bitrise client
-func GetInteractiveConfigClient() (*bitrise.Client, error) {
-	accessToken, err := askAccessToken()
-	if err != nil {
-		return nil, err
-	}
+// GetInteractiveConfigClient asks for access token and app, returns a bitrise client.
+// The access token is asked again if it is rejected by Bitrise.
+// The app is asked from the apps passing the selector's filter, unless the selector gives the app.
+func GetInteractiveConfigClient(selector AppSelector) (bitrise.API, error) {
+	for {
+		accessToken, err := AskAccessToken()
+		if err != nil {
+			return nil, err
+		}
 
-	client, err := bitrise.NewClient(accessToken)
-	if err != nil {
-		return nil, err
+		client, err := NewConfigClient(accessToken, selector)
+		if errors.Is(err, bitrise.ErrUnauthorized) {
+			log.Errorf("The access token is invalid or expired, please provide a valid one.")
+			continue
+		}
+		return client, err
 	}
+}
 
-	appList, err := client.GetAppList()
+// NewConfigClient returns a bitrise client for the given access token and the app selected by the selector.
+// The app is asked from the apps passing the selector's filter, unless the selector gives the app.
+func NewConfigClient(accessToken string, selector AppSelector) (bitrise.API, error) {
+	client, err := bitrise.NewClient(accessToken)
 	if err != nil {
 		return nil, err
 	}
 
-	selectedAppSlug, err := selectApp(appList)
+	selectedAppSlug, err := resolveAppSlug(client, selector)
 	if err != nil {
 		return nil, err
 	}
@@ -42,25 +51,36 @@ func GetInteractiveConfigClient() (*bitrise.Client, error) {
 	return client, nil
 }
 
+// UploadOptions are the attributes of the uploaded codesigning files.
+type UploadOptions struct {
+	// P12Password is the passphrase of the exported .p12 file.
+	P12Password         string
+	IdentitiesProtected bool
+	IdentitiesExposed   bool
+	ProfilesProtected   bool
+	ProfilesExposed     bool
+}
+
 // UploadCodesigningFiles ...
-func UploadCodesigningFiles(client *bitrise.Client, certificates models.Certificates, profiles []models.ProvisioningProfile) (bool, bool, error) {
+func UploadCodesigningFiles(client bitrise.API, certificates models.Certificates, profiles []models.ProvisioningProfile, options UploadOptions) (bool, bool, error) {
 	var provProfilesUploaded bool
 	if len(profiles) != 0 {
 		var err error
-		provProfilesUploaded, err = uploadExportedProvProfiles(client, profiles)
+		provProfilesUploaded, err = uploadExportedProvProfiles(client, profiles, options)
 		if err != nil {
 			return false, false, err
 		}
 	}
 
-	certsUploaded, err := uploadExportedIdentity(client, certificates)
+	certsUploaded, err := uploadExportedIdentity(client, certificates, options)
 	if err != nil {
 		return false, false, err
 	}
 	return certsUploaded, provProfilesUploaded, nil
 }
 
-func askAccessToken() (token string, err error) {
+// AskAccessToken asks for a Bitrise personal access token.
+func AskAccessToken() (token string, err error) {
 	messageToAsk := `Please copy your personal access token to Bitrise.
 (To acquire a Personal Access Token for your user, sign in with that user on bitrise.io, go to your Account Settings page,
 and select the Security tab on the left side.)`
@@ -78,41 +98,17 @@ and select the Security tab on the left side.)`
 	return accesToken, nil
 }
 
-func selectApp(appList []bitrise.Application) (seledtedAppSlug string, err error) {
-	var selectionList []string
-
-	for _, app := range appList {
-		selectionList = append(selectionList, app.Title+" ("+app.RepoURL+")")
-	}
-	userSelection, err := goinp.SelectFromStringsWithDefault("Select the app which you want to upload the provisioning profiles", 1, selectionList)
-
-	if err != nil {
-		return "", fmt.Errorf("failed to read input: %s", err)
-
-	}
-
-	log.Debugf("selected app: %v", userSelection)
-
-	for index, selected := range selectionList {
-		if selected == userSelection {
-			return appList[index].Slug, nil
-		}
-	}
-
-	return "", errors.New("failed to find selected app in appList")
-}
-
-func uploadExportedProvProfiles(bitriseClient *bitrise.Client, profilesToExport []models.ProvisioningProfile) (bool, error) {
+func uploadExportedProvProfiles(bitriseClient bitrise.API, profilesToExport []models.ProvisioningProfile, options UploadOptions) (bool, error) {
 	fmt.Println()
 	log.Infof("Uploading provisioning profiles...")
 
-	profilesToUpload, err := filterAlreadyUploadedProvProfiles(bitriseClient, profilesToExport)
+	profilesToUpload, err := FilterAlreadyUploadedProvProfiles(bitriseClient, profilesToExport)
 	if err != nil {
 		return false, err
 	}
 
 	if len(profilesToUpload) > 0 {
-		if err := uploadProvisioningProfiles(bitriseClient, profilesToUpload); err != nil {
+		if err := UploadProvisioningProfiles(bitriseClient, profilesToUpload, options); err != nil {
 			return false, err
 		}
 	} else {
@@ -122,7 +118,9 @@ func uploadExportedProvProfiles(bitriseClient *bitrise.Client, profilesToExport
 	return true, nil
 }
 
-func filterAlreadyUploadedProvProfiles(client *bitrise.Client, localProfiles []models.ProvisioningProfile) ([]models.ProvisioningProfile, error) {
+// FilterAlreadyUploadedProvProfiles returns the provisioning profiles not uploaded to the selected app yet, compared by UUID.
+// The protected provisioning profiles can not be downloaded, so they are not compared.
+func FilterAlreadyUploadedProvProfiles(client bitrise.API, localProfiles []models.ProvisioningProfile) ([]models.ProvisioningProfile, error) {
 	log.Printf("Looking for provisioning profile duplicates on Bitrise...")
 
 	uploadedProfileUUIDList := map[string]bool{}
@@ -134,6 +132,11 @@ func filterAlreadyUploadedProvProfiles(client *bitrise.Client, localProfiles []m
 	}
 
 	for _, uploadedProfileInfo := range uploadedProfInfoList {
+		if uploadedProfileInfo.IsProtected {
+			log.Warnf("Can not check %s (slug: %s) for duplicates, protected files can not be downloaded", uploadedProfileInfo.UploadFileName, uploadedProfileInfo.Slug)
+			continue
+		}
+
 		uploadedProfileUUID, err := client.GetUploadedProvisioningProfileUUIDby(uploadedProfileInfo.Slug)
 		if err != nil {
 			return nil, err
@@ -154,14 +157,15 @@ func filterAlreadyUploadedProvProfiles(client *bitrise.Client, localProfiles []m
 	return profilesToUpload, nil
 }
 
-func uploadProvisioningProfiles(bitriseClient *bitrise.Client, profilesToUpload []models.ProvisioningProfile) error {
+// UploadProvisioningProfiles uploads the provisioning profiles to the selected app.
+func UploadProvisioningProfiles(bitriseClient bitrise.API, profilesToUpload []models.ProvisioningProfile, options UploadOptions) error {
 	for _, profile := range profilesToUpload {
 		exportFileName := utility.ProfileExportFileNameNoPath(profile.Info)
 		exportSize := int64(len(profile.Content))
 
 		log.Debugf("\n%s size: %d", exportFileName, exportSize)
 
-		provProfSlugResponseData, err := bitriseClient.RegisterProvisioningProfile(exportSize, exportFileName)
+		provProfSlugResponseData, err := bitriseClient.RegisterProvisioningProfile(exportSize, exportFileName, options.ProfilesProtected, options.ProfilesExposed)
 		if err != nil {
 			return err
 		}
@@ -171,25 +175,27 @@ func uploadProvisioningProfiles(bitriseClient *bitrise.Client, profilesToUpload
 			return err
 		}
 
-		if err := bitriseClient.ConfirmProvisioningProfileUpload(provProfSlugResponseData.Slug, provProfSlugResponseData.UploadFileName); err != nil {
+		uploadedProfile, err := bitriseClient.ConfirmProvisioningProfileUpload(provProfSlugResponseData.Slug, provProfSlugResponseData.UploadFileName)
+		if err != nil {
 			return err
 		}
+		printUploadAttributes(uploadedProfile.UploadFileName, uploadedProfile.IsProtected, uploadedProfile.IsExpose)
 	}
 
 	return nil
 }
 
-func uploadExportedIdentity(bitriseClient *bitrise.Client, certificates models.Certificates) (bool, error) {
+func uploadExportedIdentity(bitriseClient bitrise.API, certificates models.Certificates, options UploadOptions) (bool, error) {
 	fmt.Println()
 	log.Infof("Uploading certificate...")
 
-	shouldUploadIdentities, err := shouldUploadCertificates(bitriseClient, certificates.Info)
+	shouldUploadIdentities, err := ShouldUploadCertificates(bitriseClient, certificates.Info)
 	if err != nil {
 		return false, err
 	}
 
 	if shouldUploadIdentities {
-		if err := uploadIdentity(bitriseClient, certificates.Content); err != nil {
+		if err := UploadIdentity(bitriseClient, certificates.Content, options); err != nil {
 			return false, err
 		}
 	} else {
@@ -199,54 +205,64 @@ func uploadExportedIdentity(bitriseClient *bitrise.Client, certificates models.C
 	return true, err
 }
 
-func shouldUploadCertificates(client *bitrise.Client, certificatesToExport []certificateutil.CertificateInfoModel) (bool, error) {
+// ShouldUploadCertificates returns true if any of the certificates is not uploaded to the selected app yet, compared by serial.
+// The protected identities can not be downloaded, so their certificates are not compared.
+func ShouldUploadCertificates(client bitrise.API, certificatesToExport []certificateutil.CertificateInfoModel) (bool, error) {
 	log.Printf("Looking for certificate duplicates on Bitrise...")
 
-	var uploadedCertificatesSerialList []string
-	localCertificatesSerialList := []string{}
-
-	uploadedItentityList, err := client.FetchUploadedIdentities()
+	uploadedSerials, err := UploadedCertificateSerials(client)
 	if err != nil {
 		return false, err
 	}
 
-	// Get uploaded certificates' serials
-	for _, uploadedIdentity := range uploadedItentityList {
-		var serialListAsString []string
+	return HasNewCertificate(uploadedSerials, certificatesToExport), nil
+}
+
+// UploadedCertificateSerials returns the serials of the certificates uploaded to the selected app.
+// The protected identities can not be downloaded, they are skipped with a warning.
+func UploadedCertificateSerials(client bitrise.API) (map[string]bool, error) {
+	uploadedIdentityList, err := client.FetchUploadedIdentities()
+	if err != nil {
+		return nil, err
+	}
+
+	serials := map[string]bool{}
+	for _, uploadedIdentity := range uploadedIdentityList {
+		if uploadedIdentity.IsProtected {
+			log.Warnf("Can not check %s (slug: %s) for duplicates, protected files can not be downloaded", uploadedIdentity.UploadFileName, uploadedIdentity.Slug)
+			continue
+		}
 
 		serialList, err := client.GetUploadedCertificatesSerialby(uploadedIdentity.Slug)
 		if err != nil {
-			return false, err
+			return nil, err
 		}
 
 		for _, serial := range serialList {
-			serialListAsString = append(serialListAsString, serial.String())
+			serials[serial.String()] = true
 		}
-		uploadedCertificatesSerialList = append(uploadedCertificatesSerialList, serialListAsString...)
-	}
-
-	for _, certificateToExport := range certificatesToExport {
-		localCertificatesSerialList = append(localCertificatesSerialList, certificateToExport.Serial)
 	}
 
-	log.Debugf("Uploaded certificates' serial list: \n\t%v", uploadedCertificatesSerialList)
-	log.Debugf("Local certificates' serial list: \n\t%v", localCertificatesSerialList)
+	log.Debugf("Uploaded certificates' serial list: \n\t%v", serials)
+	return serials, nil
+}
 
-	// Search for a new certificate
-	for _, localCertificateSerial := range localCertificatesSerialList {
-		if !sliceutil.IsStringInSlice(localCertificateSerial, uploadedCertificatesSerialList) {
-			return true, nil
+// HasNewCertificate returns true if any of the certificates' serial is not in the uploaded serials.
+func HasNewCertificate(uploadedSerials map[string]bool, certificates []certificateutil.CertificateInfoModel) bool {
+	for _, certificate := range certificates {
+		if !uploadedSerials[certificate.Serial] {
+			return true
 		}
 	}
-
-	return false, nil
+	return false
 }
 
-func uploadIdentity(bitriseClient *bitrise.Client, identities []byte) error {
+// UploadIdentity uploads the identities (.p12) to the selected app.
+func UploadIdentity(bitriseClient bitrise.API, identities []byte, options UploadOptions) error {
 	identitiesSize := int64(len(identities))
 	log.Debugf("\nIdentities size: %d", identitiesSize)
 
-	certificateResponseData, err := bitriseClient.RegisterIdentity(identitiesSize)
+	certificateResponseData, err := bitriseClient.RegisterIdentity(identitiesSize, options.P12Password, options.IdentitiesProtected, options.IdentitiesExposed)
 	if err != nil {
 		return err
 	}
@@ -256,5 +272,15 @@ func uploadIdentity(bitriseClient *bitrise.Client, identities []byte) error {
 		return err
 	}
 
-	return bitriseClient.ConfirmIdentityUpload(certificateResponseData.Slug, certificateResponseData.UploadFileName)
+	uploadedIdentity, err := bitriseClient.ConfirmIdentityUpload(certificateResponseData.Slug, certificateResponseData.UploadFileName)
+	if err != nil {
+		return err
+	}
+	printUploadAttributes(uploadedIdentity.UploadFileName, uploadedIdentity.IsProtected, uploadedIdentity.IsExpose)
+	return nil
+}
+
+// printUploadAttributes prints the attributes of an uploaded file, as stored on Bitrise.
+func printUploadAttributes(fileName string, isProtected, isExpose bool) {
+	log.Printf("%s uploaded, protected: %t, exposed to pull request builds: %t", fileName, isProtected, isExpose)
 }
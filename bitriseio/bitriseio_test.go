@@ -0,0 +1,97 @@
+package bitriseio
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/bitrise-io/codesigndoc/bitriseio/bitrise"
+	"github.com/bitrise-io/codesigndoc/models"
+	"github.com/bitrise-io/go-xcode/certificateutil"
+	"github.com/bitrise-io/go-xcode/profileutil"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeApp serves the files uploaded to a Bitrise app, the downloadable files by slug.
+// The other calls of the API panic.
+type fakeApp struct {
+	bitrise.API
+	identities []bitrise.IdentityListData
+	serials    map[string][]big.Int
+	profiles   []bitrise.ProvisioningProfileListData
+	uuids      map[string]string
+}
+
+func (app fakeApp) FetchUploadedIdentities() ([]bitrise.IdentityListData, error) {
+	return app.identities, nil
+}
+
+func (app fakeApp) GetUploadedCertificatesSerialby(identitySlug string) ([]big.Int, error) {
+	serials, ok := app.serials[identitySlug]
+	if !ok {
+		return nil, fmt.Errorf("can not download identity: %s", identitySlug)
+	}
+	return serials, nil
+}
+
+func (app fakeApp) FetchProvisioningProfiles() ([]bitrise.ProvisioningProfileListData, error) {
+	return app.profiles, nil
+}
+
+func (app fakeApp) GetUploadedProvisioningProfileUUIDby(profileSlug string) (string, error) {
+	uuid, ok := app.uuids[profileSlug]
+	if !ok {
+		return "", fmt.Errorf("can not download provisioning profile: %s", profileSlug)
+	}
+	return uuid, nil
+}
+
+func TestShouldUploadCertificates_SkipsProtectedIdentities(t *testing.T) {
+	app := fakeApp{
+		identities: []bitrise.IdentityListData{
+			{Slug: "protected", UploadFileName: "Distribution.p12", IsProtected: true},
+			{Slug: "development", UploadFileName: "Development.p12"},
+		},
+		serials: map[string][]big.Int{"development": {*big.NewInt(1)}},
+	}
+
+	shouldUpload, err := ShouldUploadCertificates(app, []certificateutil.CertificateInfoModel{{Serial: "1"}})
+	require.NoError(t, err)
+	require.False(t, shouldUpload)
+
+	shouldUpload, err = ShouldUploadCertificates(app, []certificateutil.CertificateInfoModel{{Serial: "1"}, {Serial: "2"}})
+	require.NoError(t, err)
+	require.True(t, shouldUpload)
+}
+
+func TestFilterAlreadyUploadedProvProfiles_SkipsProtectedProfiles(t *testing.T) {
+	app := fakeApp{
+		profiles: []bitrise.ProvisioningProfileListData{
+			{Slug: "protected", UploadFileName: "AppStore.mobileprovision", IsProtected: true},
+			{Slug: "development", UploadFileName: "Development.mobileprovision"},
+		},
+		uuids: map[string]string{"development": "uuid-1"},
+	}
+
+	profiles := []models.ProvisioningProfile{
+		{Info: profileutil.ProvisioningProfileInfoModel{UUID: "uuid-1"}},
+		{Info: profileutil.ProvisioningProfileInfoModel{UUID: "uuid-2"}},
+	}
+	profilesToUpload, err := FilterAlreadyUploadedProvProfiles(app, profiles)
+	require.NoError(t, err)
+	require.Equal(t, profiles[1:], profilesToUpload)
+}
+
+func TestDownloadCodesigningFiles_SkipsProtectedFiles(t *testing.T) {
+	app := fakeApp{
+		identities: []bitrise.IdentityListData{{Slug: "protected", UploadFileName: "Distribution.p12", IsProtected: true}},
+		profiles: []bitrise.ProvisioningProfileListData{
+			{Slug: "protected-1", UploadFileName: "AppStore.mobileprovision", IsProtected: true},
+			{Slug: "protected-2", UploadFileName: "AdHoc.mobileprovision", IsProtected: true},
+		},
+	}
+
+	files, err := DownloadCodesigningFiles(app)
+	require.NoError(t, err)
+	require.Equal(t, DownloadedFiles{ProtectedIdentities: 1, ProtectedProvisioningProfiles: 2}, files)
+}
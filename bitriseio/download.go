@@ -0,0 +1,124 @@
+package bitriseio
+
+import (
+	"fmt"
+
+	"github.com/bitrise-io/codesigndoc/bitriseio/bitrise"
+	"github.com/bitrise-io/codesigndoc/models"
+	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-io/go-xcode/certificateutil"
+	"github.com/bitrise-io/go-xcode/profileutil"
+)
+
+// DownloadedFiles are the code signing files downloaded from a Bitrise app.
+type DownloadedFiles struct {
+	Identities           []models.Identities
+	ProvisioningProfiles []models.ProvisioningProfile
+	// ProtectedIdentities and ProtectedProvisioningProfiles are the number of skipped protected files, which can not be downloaded.
+	ProtectedIdentities           int
+	ProtectedProvisioningProfiles int
+}
+
+// DownloadCodesigningFiles downloads every identity (.p12) and provisioning profile uploaded to the selected app.
+// The identities are decrypted with their stored passphrase.
+// The protected files can not be downloaded, they are skipped with a warning.
+func DownloadCodesigningFiles(client bitrise.API) (DownloadedFiles, error) {
+	var files DownloadedFiles
+
+	fmt.Println()
+	log.Infof("Downloading certificates...")
+
+	identityList, err := client.FetchUploadedIdentities()
+	if err != nil {
+		return DownloadedFiles{}, fmt.Errorf("failed to list the uploaded identities, error: %s", err)
+	}
+
+	for _, identityData := range identityList {
+		if identityData.IsProtected {
+			log.Warnf("Skipping protected %s (slug: %s), protected files can not be downloaded", identityData.UploadFileName, identityData.Slug)
+			files.ProtectedIdentities++
+			continue
+		}
+
+		identity, err := DownloadIdentity(client, identityData)
+		if err != nil {
+			return DownloadedFiles{}, err
+		}
+		files.Identities = append(files.Identities, identity)
+	}
+
+	fmt.Println()
+	log.Infof("Downloading provisioning profiles...")
+
+	profileList, err := client.FetchProvisioningProfiles()
+	if err != nil {
+		return DownloadedFiles{}, fmt.Errorf("failed to list the uploaded provisioning profiles, error: %s", err)
+	}
+
+	for _, profileData := range profileList {
+		if profileData.IsProtected {
+			log.Warnf("Skipping protected %s (slug: %s), protected files can not be downloaded", profileData.UploadFileName, profileData.Slug)
+			files.ProtectedProvisioningProfiles++
+			continue
+		}
+
+		profile, err := DownloadProvisioningProfile(client, profileData)
+		if err != nil {
+			return DownloadedFiles{}, err
+		}
+		files.ProvisioningProfiles = append(files.ProvisioningProfiles, profile)
+	}
+
+	return files, nil
+}
+
+// DownloadIdentity downloads an uploaded identities (.p12) file and decrypts it with its stored passphrase.
+func DownloadIdentity(client bitrise.API, identityData bitrise.IdentityListData) (models.Identities, error) {
+	log.Printf("Downloading %s...", identityData.UploadFileName)
+
+	content, password, err := client.DownloadIdentity(identityData.Slug)
+	if err != nil {
+		return models.Identities{}, fmt.Errorf("failed to download the uploaded identity (%s), error: %s", identityData.UploadFileName, err)
+	}
+
+	certificates, err := certificateutil.CertificatesFromPKCS12Content(content, password)
+	if err != nil {
+		return models.Identities{}, fmt.Errorf("failed to open the uploaded identity (%s) with its stored passphrase, error: %s", identityData.UploadFileName, err)
+	}
+	for _, certificate := range certificates {
+		log.Printf("- %s", certificate.CommonName)
+	}
+
+	return models.Identities{
+		FileName: identityData.UploadFileName,
+		Password: password,
+		Info:     certificates,
+		Content:  content,
+	}, nil
+}
+
+// DownloadProvisioningProfile downloads and parses an uploaded provisioning profile.
+func DownloadProvisioningProfile(client bitrise.API, profileData bitrise.ProvisioningProfileListData) (models.ProvisioningProfile, error) {
+	log.Printf("Downloading %s...", profileData.UploadFileName)
+
+	content, err := client.DownloadProvisioningProfile(profileData.Slug)
+	if err != nil {
+		return models.ProvisioningProfile{}, fmt.Errorf("failed to download the uploaded provisioning profile (%s), error: %s", profileData.UploadFileName, err)
+	}
+
+	pkcs7, err := profileutil.ProvisioningProfileFromContent(content)
+	if err != nil {
+		return models.ProvisioningProfile{}, fmt.Errorf("failed to parse the uploaded provisioning profile (%s), error: %s", profileData.UploadFileName, err)
+	}
+
+	info, err := profileutil.NewProvisioningProfileInfo(*pkcs7)
+	if err != nil {
+		return models.ProvisioningProfile{}, fmt.Errorf("failed to parse the uploaded provisioning profile (%s), error: %s", profileData.UploadFileName, err)
+	}
+	log.Printf("- %s (UUID: %s)", info.Name, info.UUID)
+
+	return models.ProvisioningProfile{
+		Info:    info,
+		Content: content,
+	}, nil
+}
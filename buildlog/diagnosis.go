@@ -0,0 +1,252 @@
+package buildlog
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/bitrise-io/go-utils/colorstring"
+	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-io/go-utils/stringutil"
+)
+
+// FailureKind is a known code signing failure of xcodebuild.
+type FailureKind string
+
+// Failure kinds ...
+const (
+	NoProfilesFound          FailureKind = "no-profiles-found"
+	ProfileRequired          FailureKind = "profile-required"
+	CertificateNotInProfile  FailureKind = "certificate-not-in-profile"
+	CapabilityNotSupported   FailureKind = "capability-not-supported"
+	AutomaticSigningDisabled FailureKind = "automatic-signing-disabled"
+	NoSigningCertificate     FailureKind = "no-signing-certificate"
+	KeychainAccess           FailureKind = "keychain-access"
+)
+
+// Diagnosis is a code signing failure found in the xcodebuild output.
+type Diagnosis struct {
+	Kind FailureKind
+	// Target is the target failing to sign, empty if the output does not name it.
+	Target string
+	// BundleID is the bundle ID failing to sign, empty if the output does not name it.
+	BundleID string
+	// Profile is the provisioning profile named by the error, if any.
+	Profile string
+	// Detail is the certificate, capability or profile type named by the error, if any.
+	Detail string
+	// Message is the error line of the xcodebuild output.
+	Message string
+	// Fix describes how to fix the failure.
+	Fix string
+}
+
+// failureRule matches an error line of the xcodebuild output, and describes the failure and its fix.
+type failureRule struct {
+	kind    FailureKind
+	pattern *regexp.Regexp
+	// diagnose fills the diagnosis from the submatches of the pattern,
+	// the fix suggests the provisioningUpdatesFlag only if it is not empty.
+	diagnose func(d *Diagnosis, submatches []string, provisioningUpdatesFlag string)
+}
+
+// failureRules are matched in order against every line, the first matching rule describes the line.
+var failureRules = []failureRule{
+	{
+		// error: No profiles for 'io.bitrise.app' were found: Xcode couldn't find any iOS App Development provisioning profiles matching 'io.bitrise.app'. ...
+		kind:    NoProfilesFound,
+		pattern: regexp.MustCompile(`No profiles for '([^']+)' were found(?:: Xcode couldn't find any (.+?) provisioning profiles)?`),
+		diagnose: func(d *Diagnosis, submatches []string, provisioningUpdatesFlag string) {
+			d.BundleID = submatches[1]
+			d.Detail = submatches[2]
+			profileType := "matching"
+			if d.Detail != "" {
+				profileType = d.Detail
+			}
+			d.Fix = fmt.Sprintf("Create a %s provisioning profile for %s on the Apple Developer Portal and install it, ", profileType, d.BundleID)
+			if provisioningUpdatesFlag != "" {
+				d.Fix += fmt.Sprintf("or let Xcode create it with automatic signing and the %s flag.", provisioningUpdatesFlag)
+			} else {
+				d.Fix += "or let Xcode create it by building the target with automatic signing in Xcode."
+			}
+		},
+	},
+	{
+		// error: "App" requires a provisioning profile. Select a provisioning profile in the Signing & Capabilities editor.
+		kind:    ProfileRequired,
+		pattern: regexp.MustCompile(`"([^"]+)" requires a provisioning profile`),
+		diagnose: func(d *Diagnosis, submatches []string, _ string) {
+			if d.Target == "" {
+				d.Target = submatches[1]
+			}
+			d.Fix = fmt.Sprintf("Select a provisioning profile for the %s target in the Signing & Capabilities editor (or set PROVISIONING_PROFILE_SPECIFIER), or enable automatic signing.", submatches[1])
+		},
+	},
+	{
+		// error: Provisioning profile "App Store" doesn't include signing certificate "Apple Distribution: Team (ABCD1234)".
+		kind:    CertificateNotInProfile,
+		pattern: regexp.MustCompile(`Provisioning profile "([^"]+)" doesn't include signing certificate "([^"]+)"`),
+		diagnose: func(d *Diagnosis, submatches []string, _ string) {
+			d.Profile = submatches[1]
+			d.Detail = submatches[2]
+			d.Fix = fmt.Sprintf("Regenerate the %s provisioning profile including the %s certificate on the Apple Developer Portal, or install a certificate (with its private key) included in the profile.", d.Profile, d.Detail)
+		},
+	},
+	{
+		// error: Provisioning profile "Development" doesn't support the Push Notifications capability.
+		// error: Provisioning profile "Development" doesn't include the aps-environment entitlement.
+		kind:    CapabilityNotSupported,
+		pattern: regexp.MustCompile(`Provisioning profile "([^"]+)" doesn't (?:support the (.+?) capability|include the (.+?) entitlement)`),
+		diagnose: func(d *Diagnosis, submatches []string, _ string) {
+			d.Profile = submatches[1]
+			d.Detail = submatches[2]
+			if d.Detail == "" {
+				d.Detail = submatches[3]
+			}
+			d.Fix = fmt.Sprintf("Enable %s for the App ID on the Apple Developer Portal and regenerate the %s provisioning profile, or remove it from the target's Signing & Capabilities.", d.Detail, d.Profile)
+		},
+	},
+	{
+		// error: Automatic signing is disabled and unable to generate a profile. To enable automatic signing, pass -allowProvisioningUpdates to xcodebuild.
+		kind:    AutomaticSigningDisabled,
+		pattern: regexp.MustCompile(`Automatic signing is disabled`),
+		diagnose: func(d *Diagnosis, _ []string, provisioningUpdatesFlag string) {
+			if provisioningUpdatesFlag != "" {
+				d.Fix = fmt.Sprintf("Scan with the %s flag to let Xcode create and update the provisioning profiles, or switch the target to manual signing and select a provisioning profile.", provisioningUpdatesFlag)
+				return
+			}
+			d.Fix = "Build the target in Xcode to let it create and update the provisioning profiles, or switch the target to manual signing and select a provisioning profile."
+		},
+	},
+	{
+		// error: No signing certificate "iOS Distribution" found: No "iOS Distribution" signing certificate matching team ID "ABCD1234" with a private key was found.
+		kind:    NoSigningCertificate,
+		pattern: regexp.MustCompile(`No signing certificate "([^"]+)" found`),
+		diagnose: func(d *Diagnosis, submatches []string, _ string) {
+			d.Detail = submatches[1]
+			d.Fix = fmt.Sprintf("Install a %s certificate of the team with its private key into the keychain, i.e. by importing its .p12 file.", d.Detail)
+		},
+	},
+	{
+		// /path/App.app: errSecInternalComponent
+		// error: The specified item could not be found in the keychain.
+		kind:    KeychainAccess,
+		pattern: regexp.MustCompile(`(errSecInternalComponent|User interaction is not allowed|could not be found in the keychain|unable to build chain to self-signed root)`),
+		diagnose: func(d *Diagnosis, submatches []string, _ string) {
+			d.Detail = submatches[1]
+			if d.Detail == "unable to build chain to self-signed root" {
+				d.Fix = "Install the Apple Worldwide Developer Relations intermediate certificate (https://www.apple.com/certificateauthority/) into the keychain."
+				return
+			}
+			d.Fix = "Unlock the keychain with `security unlock-keychain` and allow codesign to access the private key with `security set-key-partition-list -S apple-tool:,apple:,codesign: -s <keychain>`, then try again."
+		},
+	},
+}
+
+var targetPattern = regexp.MustCompile(`\(in target '([^']+)' from project '[^']+'\)`)
+
+// Diagnose returns the known code signing failures found in the xcodebuild output, in order of appearance.
+// The same failure reported more than once (i.e. in the build log and in the summary) is returned once.
+// The target of a failure is read from the failing line or, for the codesign errors, from the last preceding line naming a target.
+// The provisioningUpdatesFlag is the flag of the calling command passing -allowProvisioningUpdates to xcodebuild
+// (i.e. --allow-provisioning-updates), the fixes suggest it only if it is not empty.
+func Diagnose(xcodebuildOutput, provisioningUpdatesFlag string) []Diagnosis {
+	var diagnoses []Diagnosis
+	seen := map[Diagnosis]bool{}
+	lastTarget := ""
+
+	for _, line := range strings.Split(xcodebuildOutput, "\n") {
+		line = strings.TrimSpace(line)
+		lineTarget := ""
+		if match := targetPattern.FindStringSubmatch(line); match != nil {
+			lineTarget = match[1]
+		}
+
+		for _, rule := range failureRules {
+			submatches := rule.pattern.FindStringSubmatch(line)
+			if submatches == nil {
+				continue
+			}
+
+			diagnosis := Diagnosis{Kind: rule.kind, Target: lineTarget}
+			if diagnosis.Target == "" && rule.kind == KeychainAccess {
+				diagnosis.Target = lastTarget
+			}
+			rule.diagnose(&diagnosis, submatches, provisioningUpdatesFlag)
+
+			if !seen[diagnosis] {
+				seen[diagnosis] = true
+				diagnosis.Message = line
+				diagnoses = append(diagnoses, diagnosis)
+			}
+			break
+		}
+
+		if lineTarget != "" {
+			lastTarget = lineTarget
+		}
+	}
+
+	diagnoses = removeDuplicates(diagnoses)
+
+	// The bundle ID is named by some of the errors only, it is filled for the other failures of the same target.
+	targetBundleIDs := map[string]string{}
+	for _, diagnosis := range diagnoses {
+		if diagnosis.Target != "" && diagnosis.BundleID != "" {
+			targetBundleIDs[diagnosis.Target] = diagnosis.BundleID
+		}
+	}
+	for i, diagnosis := range diagnoses {
+		if diagnosis.BundleID == "" && diagnosis.Target != "" {
+			diagnoses[i].BundleID = targetBundleIDs[diagnosis.Target]
+		}
+	}
+
+	return diagnoses
+}
+
+// removeDuplicates removes the failures reported again without the target, i.e. in the summary of an older Xcode.
+func removeDuplicates(diagnoses []Diagnosis) []Diagnosis {
+	var unique []Diagnosis
+	for _, diagnosis := range diagnoses {
+		if diagnosis.Target == "" && hasTargetedDuplicate(diagnoses, diagnosis) {
+			continue
+		}
+		unique = append(unique, diagnosis)
+	}
+	return unique
+}
+
+func hasTargetedDuplicate(diagnoses []Diagnosis, diagnosis Diagnosis) bool {
+	for _, other := range diagnoses {
+		if other.Target != "" && other.Kind == diagnosis.Kind && other.BundleID == diagnosis.BundleID && other.Profile == diagnosis.Profile && other.Detail == diagnosis.Detail {
+			return true
+		}
+	}
+	return false
+}
+
+// PrintFailure prints the code signing failures found in the xcodebuild output with their fixes,
+// or the last lines of the output if none is found. See Diagnose for the provisioningUpdatesFlag.
+func PrintFailure(xcodebuildOutput, provisioningUpdatesFlag string) {
+	diagnoses := Diagnose(xcodebuildOutput, provisioningUpdatesFlag)
+	if len(diagnoses) == 0 {
+		log.Warnf("Last lines of the build log:")
+		fmt.Println(stringutil.LastNLines(xcodebuildOutput, 15))
+		return
+	}
+
+	log.Warnf("Code signing failures found in the build log:")
+	for _, diagnosis := range diagnoses {
+		fmt.Println()
+		log.Errorf("%s", diagnosis.Message)
+		if diagnosis.Target != "" {
+			log.Printf("Target: %s", diagnosis.Target)
+		}
+		if diagnosis.BundleID != "" {
+			log.Printf("Bundle ID: %s", diagnosis.BundleID)
+		}
+		log.Printf(colorstring.Yellow("Fix: ")+"%s", diagnosis.Fix)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,107 @@
+package buildlog
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiagnose(t *testing.T) {
+	tests := []struct {
+		logFile string
+		want    []Diagnosis
+	}{
+		{
+			logFile: "no-profiles-found.log",
+			want: []Diagnosis{
+				{Kind: NoProfilesFound, Target: "Runner", BundleID: "io.bitrise.flutter.sample", Detail: "iOS App Store"},
+			},
+		},
+		{
+			logFile: "requires-provisioning-profile.log",
+			want: []Diagnosis{
+				{Kind: ProfileRequired, Target: "Sample"},
+				{Kind: ProfileRequired, Target: "ShareExtension"},
+			},
+		},
+		{
+			logFile: "certificate-not-in-profile.log",
+			want: []Diagnosis{
+				{Kind: CertificateNotInProfile, Target: "App", Profile: "App Store io.ionic.starter", Detail: "Apple Distribution: Bitrise Ltd (72SA8V3WYL)"},
+			},
+		},
+		{
+			logFile: "capability-not-supported.log",
+			want: []Diagnosis{
+				{Kind: CapabilityNotSupported, Target: "AwesomeProject", Profile: "AwesomeProject Development", Detail: "Push Notifications"},
+				{Kind: CapabilityNotSupported, Target: "AwesomeProject", Profile: "AwesomeProject Development", Detail: "aps-environment"},
+			},
+		},
+		{
+			// The errors are listed again in the "Testing failed:" summary, without the target.
+			logFile: "automatic-signing-disabled.log",
+			want: []Diagnosis{
+				{Kind: AutomaticSigningDisabled, Target: "SampleUITests"},
+				{Kind: NoSigningCertificate, Target: "SampleUITests", Detail: "iOS Development"},
+			},
+		},
+		{
+			logFile: "keychain-access.log",
+			want: []Diagnosis{
+				{Kind: KeychainAccess, Target: "Sample", Detail: "errSecInternalComponent"},
+			},
+		},
+		{
+			logFile: "compile-error.log",
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.logFile, func(t *testing.T) {
+			content, err := ioutil.ReadFile(filepath.Join("testdata", tt.logFile))
+			require.NoError(t, err)
+
+			diagnoses := Diagnose(string(content), "--allow-provisioning-updates")
+			for i, diagnosis := range diagnoses {
+				require.NotEmpty(t, diagnosis.Message)
+				require.NotEmpty(t, diagnosis.Fix)
+				diagnoses[i].Message = ""
+				diagnoses[i].Fix = ""
+			}
+			require.Equal(t, tt.want, diagnoses)
+		})
+	}
+}
+
+func TestDiagnoseRemovesDuplicates(t *testing.T) {
+	output := `error: No profiles for 'io.bitrise.app' were found: Xcode couldn't find any iOS App Development provisioning profiles matching 'io.bitrise.app'. (in target 'App' from project 'App')
+error: No profiles for 'io.bitrise.app' were found: Xcode couldn't find any iOS App Development provisioning profiles matching 'io.bitrise.app'. (in target 'App' from project 'App')
+Code Signing Error: No profiles for 'io.bitrise.app' were found: Xcode couldn't find any iOS App Development provisioning profiles matching 'io.bitrise.app'.
+error: Provisioning profile "App Development" doesn't support the iCloud capability. (in target 'App' from project 'App')`
+
+	diagnoses := Diagnose(output, "")
+	require.Len(t, diagnoses, 2)
+	require.Equal(t, NoProfilesFound, diagnoses[0].Kind)
+	require.Equal(t, CapabilityNotSupported, diagnoses[1].Kind)
+	// The bundle ID of the target is known from the first failure
+	require.Equal(t, "io.bitrise.app", diagnoses[1].BundleID)
+}
+
+func TestDiagnoseProvisioningUpdatesFlag(t *testing.T) {
+	output := `error: No profiles for 'io.bitrise.app' were found: Xcode couldn't find any iOS App Development provisioning profiles matching 'io.bitrise.app'. (in target 'App' from project 'App')
+error: Automatic signing is disabled and unable to generate a profile. To enable automatic signing, pass -allowProvisioningUpdates to xcodebuild. (in target 'App' from project 'App')`
+
+	diagnoses := Diagnose(output, "--allow-provisioning-updates")
+	require.Len(t, diagnoses, 2)
+	for _, diagnosis := range diagnoses {
+		require.Contains(t, diagnosis.Fix, "--allow-provisioning-updates")
+	}
+
+	diagnoses = Diagnose(output, "")
+	require.Len(t, diagnoses, 2)
+	for _, diagnosis := range diagnoses {
+		require.NotContains(t, diagnosis.Fix, "--allow-provisioning-updates")
+	}
+}
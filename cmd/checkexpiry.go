@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bitrise-io/codesigndoc/bitriseio/bitrise"
+	"github.com/bitrise-io/codesigndoc/codesign"
+	"github.com/bitrise-io/go-utils/colorstring"
+	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/bitrise-io/go-xcode/profileutil"
+	"github.com/spf13/cobra"
+)
+
+// Exit codes of the check-expiry command.
+const (
+	exitCodeExpiringSoon = 2
+	exitCodeExpired      = 3
+)
+
+// checkExpiryCmd represents the check-expiry command.
+var checkExpiryCmd = &cobra.Command{
+	Use:   "check-expiry",
+	Short: "Reports the certificates and provisioning profiles expiring soon",
+	Long: `Reports the certificates and provisioning profiles expiring soon.
+
+Checks the keychain's identities and the installed provisioning profiles by default,
+the files of an export directory if --export-dir is set,
+or the files uploaded to a Bitrise app if --auth-token and --app-slug are set.
+
+Exit codes:
+  0: every file is valid for longer than the given number of days
+  2: a file is expiring within the given number of days
+  3: a file is expired`,
+
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          checkExpiry,
+}
+
+var (
+	paramExpiryDays      int
+	paramExportDir       string
+	paramP12Password     string
+	paramExpiryAuthToken string
+	paramExpiryAppSlug   string
+)
+
+func init() {
+	RootCmd.AddCommand(checkExpiryCmd)
+
+	checkExpiryCmd.Flags().IntVar(&paramExpiryDays, "days", 30, "Report the files expiring within this number of days.")
+	checkExpiryCmd.Flags().StringVar(&paramExportDir, "export-dir", "", "Check the code signing files of the given export directory (i.e. ./codesigndoc_exports).")
+	checkExpiryCmd.Flags().StringVar(&paramP12Password, "p12-password", "", "Passphrase of the .p12 files of the export directory.")
+	checkExpiryCmd.Flags().StringVar(&paramExpiryAuthToken, authTokenFlag, "", "Bitrise personal access token, check the files uploaded to a Bitrise app. Requires the app-slug parameter to be also set.")
+	checkExpiryCmd.Flags().StringVar(&paramExpiryAppSlug, appSlugFlag, "", "Bitrise app slug, check the files uploaded to the Bitrise app. Requires the auth-token parameter to be also set.")
+}
+
+func checkExpiry(_ *cobra.Command, _ []string) error {
+	if paramExpiryDays < 0 {
+		return fmt.Errorf("invalid value for days flag: %d", paramExpiryDays)
+	}
+	if paramExpiryAppSlug != "" && paramExpiryAuthToken == "" || paramExpiryAppSlug == "" && paramExpiryAuthToken != "" {
+		return fmt.Errorf("both or none flags %s and %s are required to be set", appSlugFlag, authTokenFlag)
+	}
+	if paramExportDir != "" && paramExpiryAppSlug != "" {
+		return fmt.Errorf("the --export-dir flag can not be used together with the %s flag", appSlugFlag)
+	}
+
+	now := time.Now()
+	window := time.Duration(paramExpiryDays) * 24 * time.Hour
+
+	var items []codesign.ExpiryItem
+	var protected int
+	var err error
+	switch {
+	case paramExportDir != "":
+		items, err = exportDirExpiry(paramExportDir, paramP12Password, now, window)
+	case paramExpiryAppSlug != "":
+		items, protected, err = bitriseAppExpiry(paramExpiryAuthToken, paramExpiryAppSlug, now, window)
+	default:
+		items, err = localExpiry(now, window)
+	}
+	if err != nil {
+		return err
+	}
+
+	codesign.SortExpiryItems(items)
+
+	fmt.Println()
+	log.Infof("Checked %d certificates and provisioning profiles, expiry window: %d days", len(items), paramExpiryDays)
+	if protected > 0 {
+		log.Printf("Skipped %d protected file(s), protected files can not be downloaded", protected)
+	}
+	for _, item := range items {
+		line := fmt.Sprintf("- %s: %s (%s), expires: %s [%s]", item.Kind, item.Name, item.ID, item.ExpirationDate, item.Source)
+		switch item.Status {
+		case codesign.ExpiryStatusExpired:
+			fmt.Println(colorstring.Red(line + " - expired"))
+		case codesign.ExpiryStatusExpiringSoon:
+			fmt.Println(colorstring.Yellow(line + " - expiring soon"))
+		default:
+			log.Debugf(line)
+		}
+	}
+
+	switch codesign.WorstExpiryStatus(items) {
+	case codesign.ExpiryStatusExpired:
+		return ExitCodeError{exitCodeExpired, colorstring.Red("Expired certificates or provisioning profiles found.")}
+	case codesign.ExpiryStatusExpiringSoon:
+		return ExitCodeError{exitCodeExpiringSoon, colorstring.Yellowf("Certificates or provisioning profiles expiring within %d days found.", paramExpiryDays)}
+	}
+
+	fmt.Println()
+	log.Successf("Every certificate and provisioning profile is valid for more than %d days.", paramExpiryDays)
+	return nil
+}
+
+func localExpiry(now time.Time, window time.Duration) ([]codesign.ExpiryItem, error) {
+	identities, err := codesign.InstalledKeychainIdentities()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list code signing identities, error: %s", err)
+	}
+
+	items := codesign.CertificatesExpiry(identities.Certificates, "keychain", now, window)
+
+	for _, profileType := range []profileutil.ProfileType{profileutil.ProfileTypeIos, profileutil.ProfileTypeMacOs} {
+		profiles, err := profileutil.InstalledProvisioningProfileInfos(profileType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list installed provisioning profiles, error: %s", err)
+		}
+		items = append(items, codesign.ProfilesExpiry(profiles, "installed", now, window)...)
+	}
+
+	return items, nil
+}
+
+func exportDirExpiry(dir, p12Password string, now time.Time, window time.Duration) ([]codesign.ExpiryItem, error) {
+	absDir, err := pathutil.AbsPath(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine absolute path of export dir: %s", dir)
+	}
+
+	certificates, profiles, err := codesign.ReadExportDir(absDir, p12Password)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(codesign.CertificatesExpiry(certificates, absDir, now, window), codesign.ProfilesExpiry(profiles, absDir, now, window)...), nil
+}
+
+// bitriseAppExpiry returns the expiry status of the files uploaded to the Bitrise app and the number of the skipped protected files,
+// which can not be downloaded.
+func bitriseAppExpiry(accessToken, appSlug string, now time.Time, window time.Duration) ([]codesign.ExpiryItem, int, error) {
+	client, err := bitrise.NewClient(accessToken)
+	if err != nil {
+		return nil, 0, err
+	}
+	client.SetSelectedAppSlug(appSlug)
+
+	var items []codesign.ExpiryItem
+	var protected int
+
+	identities, err := client.FetchUploadedIdentities()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list the uploaded identities, error: %s", err)
+	}
+	for _, identity := range identities {
+		if identity.IsProtected {
+			log.Warnf("Skipping protected %s (slug: %s), protected files can not be downloaded", identity.UploadFileName, identity.Slug)
+			protected++
+			continue
+		}
+
+		certificates, err := client.GetUploadedCertificates(identity.Slug)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to download the uploaded identity (%s), error: %s", identity.UploadFileName, err)
+		}
+		items = append(items, codesign.CertificatesExpiry(certificates, "bitrise: "+identity.UploadFileName, now, window)...)
+	}
+
+	profiles, err := client.FetchProvisioningProfiles()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list the uploaded provisioning profiles, error: %s", err)
+	}
+	for _, profileData := range profiles {
+		if profileData.IsProtected {
+			log.Warnf("Skipping protected %s (slug: %s), protected files can not be downloaded", profileData.UploadFileName, profileData.Slug)
+			protected++
+			continue
+		}
+
+		profile, err := client.GetUploadedProvisioningProfile(profileData.Slug)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to download the uploaded provisioning profile (%s), error: %s", profileData.UploadFileName, err)
+		}
+		items = append(items, codesign.ProfilesExpiry([]profileutil.ProvisioningProfileInfoModel{profile}, "bitrise: "+profileData.UploadFileName, now, window)...)
+	}
+
+	return items, protected, nil
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/bitrise-io/codesigndoc/crossplatform"
+	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/bitrise-io/goinp/goinp"
+	"github.com/spf13/cobra"
+)
+
+// flutterCmd represents the flutter command.
+var flutterCmd = &cobra.Command{
+	Use:   "flutter",
+	Short: "Scans the code signing settings of a Flutter project's iOS or macOS app",
+	Long: `Scans the code signing settings of a Flutter project's iOS or macOS app
+and exports the required code signing files.
+
+The native project is ios/Runner.xcworkspace (or macos/Runner.xcworkspace with --platform macos).
+A flavor is archived with the scheme named as the flavor and the Release-<flavor> build configuration, like flutter build does.`,
+
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return scanCrossPlatformProject(crossplatform.Flutter)
+	},
+}
+
+// reactNativeCmd represents the react-native command.
+var reactNativeCmd = &cobra.Command{
+	Use:   "react-native",
+	Short: "Scans the code signing settings of a React Native project's iOS or macOS app",
+	Long: `Scans the code signing settings of a React Native project's iOS or macOS app
+and exports the required code signing files.
+
+The native project is the workspace in the ios (or macos with --platform macos) directory.`,
+
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return scanCrossPlatformProject(crossplatform.ReactNative)
+	},
+}
+
+// capacitorCmd represents the capacitor command.
+var capacitorCmd = &cobra.Command{
+	Use:   "capacitor",
+	Short: "Scans the code signing settings of an Ionic/Capacitor project's iOS app",
+	Long: `Scans the code signing settings of an Ionic/Capacitor project's iOS app
+and exports the required code signing files.
+
+The native project is ios/App/App.xcworkspace.`,
+
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return scanCrossPlatformProject(crossplatform.Capacitor)
+	},
+}
+
+var (
+	paramProjectDir                 string
+	paramPlatform                   string
+	paramFlutterFlavor              string
+	paramCrossPlatformScheme        string
+	paramCrossPlatformExportMethod  string
+	paramCrossPlatformConfiguration string
+	paramCrossPlatformWithUITests   bool
+)
+
+func init() {
+	for _, cmd := range []*cobra.Command{flutterCmd, reactNativeCmd, capacitorCmd} {
+		scanCmd.AddCommand(cmd)
+
+		cmd.Flags().StringVar(&paramProjectDir, "project-dir", ".", "Root directory of the project.")
+		cmd.Flags().StringVar(&paramCrossPlatformExportMethod, "export-method", "", `Comma separated list of export methods to collect the code signing files for, i.e "development,ad-hoc,app-store".`)
+		cmd.Flags().StringVar(&paramCrossPlatformConfiguration, "configuration", "", "Build configuration to archive with. Defaults to the build configuration of the scheme's archive action.")
+		cmd.Flags().BoolVar(&paramCrossPlatformWithUITests, "with-uitests", false, "Run a build-for-testing after the archive and collect the code signing files of the UI test targets too.")
+	}
+
+	flutterCmd.Flags().StringVar(&paramPlatform, "platform", string(crossplatform.IOS), `Platform of the app to scan: "ios" or "macos".`)
+	flutterCmd.Flags().StringVar(&paramFlutterFlavor, "flavor", "", "Flutter flavor to scan. By default codesigndoc will ask for it interactively if the project has flavors.")
+
+	reactNativeCmd.Flags().StringVar(&paramPlatform, "platform", string(crossplatform.IOS), `Platform of the app to scan: "ios" or "macos".`)
+	reactNativeCmd.Flags().StringVar(&paramCrossPlatformScheme, "scheme", "", "Xcode Scheme")
+
+	capacitorCmd.Flags().StringVar(&paramCrossPlatformScheme, "scheme", "", "Xcode Scheme")
+}
+
+// scanCrossPlatformProject scans the native Xcode project of the cross-platform project,
+// after warning about the missing prerequisites of archiving it.
+func scanCrossPlatformProject(framework crossplatform.Framework) error {
+	absProjectDir, err := pathutil.AbsPath(paramProjectDir)
+	if err != nil {
+		return fmt.Errorf("failed to determine absolute path of project dir: %s", paramProjectDir)
+	}
+
+	platform := crossplatform.IOS
+	if paramPlatform != "" {
+		if platform, err = crossplatform.ParsePlatform(paramPlatform); err != nil {
+			return err
+		}
+	}
+
+	project, err := crossplatform.FindProject(framework, absProjectDir, platform)
+	if err != nil {
+		return err
+	}
+	log.Infof("%s project found: %s", framework, project.Path)
+
+	if warnings := project.MissingPrerequisites(); len(warnings) > 0 {
+		fmt.Println()
+		log.Warnf("The archive will likely fail:")
+		for _, warning := range warnings {
+			log.Warnf("- %s", warning)
+		}
+	}
+
+	options := xcodeScanOptions{
+		ProjectPath:   project.Path,
+		Scheme:        paramCrossPlatformScheme,
+		ExportMethod:  paramCrossPlatformExportMethod,
+		Configuration: paramCrossPlatformConfiguration,
+		WithUITests:   paramCrossPlatformWithUITests,
+	}
+	if framework == crossplatform.Flutter {
+		if err := selectFlutterFlavor(project, &options); err != nil {
+			return err
+		}
+	}
+
+	return scanXcode(options)
+}
+
+// selectFlutterFlavor sets the scheme and the build configuration of the scan options to the Flutter flavor set by the --flavor flag,
+// the flavor is asked if the flag is not set and the project has flavors.
+func selectFlutterFlavor(project crossplatform.Project, options *xcodeScanOptions) error {
+	flavors, err := crossplatform.FlutterFlavors(project)
+	if err != nil {
+		return fmt.Errorf("failed to list the Flutter flavors, error: %s", err)
+	}
+	log.Debugf("flavors: %v", flavors)
+
+	flavor := paramFlutterFlavor
+	if flavor == "" && len(flavors) > 0 {
+		fmt.Println()
+		if flavor, err = goinp.SelectFromStringsWithDefault("Select the Flutter flavor to scan", 1, flavors); err != nil {
+			return fmt.Errorf("failed to select flavor: %s", err)
+		}
+	}
+
+	scheme, configuration, err := crossplatform.FlutterFlavorSchemeAndConfiguration(flavors, flavor)
+	if err != nil {
+		return err
+	}
+
+	options.Scheme = scheme
+	if options.Configuration == "" {
+		options.Configuration = configuration
+	}
+	if flavor != "" {
+		log.Printf("Scanning flavor %s with scheme %s and configuration %s", flavor, options.Scheme, options.Configuration)
+	}
+	return nil
+}
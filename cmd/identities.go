@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bitrise-io/codesigndoc/codesign"
+	"github.com/bitrise-io/go-utils/colorstring"
+	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-io/go-utils/sliceutil"
+	"github.com/bitrise-io/go-xcode/profileutil"
+	"github.com/spf13/cobra"
+)
+
+// identitiesCmd represents the identities command.
+var identitiesCmd = &cobra.Command{
+	Use:   "identities",
+	Short: "Code signing identity utilities",
+	Long:  `Code signing identity utilities`,
+}
+
+// identitiesListCmd represents the identities list command.
+var identitiesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the code signing identities (certificates) of the keychain",
+	Long: `Lists the code signing identities (certificates) of the keychain.
+
+Every iOS, macOS and installer certificate is listed, including the expired ones and the ones
+codesigndoc filters out, with the reason of the filtering and the installed provisioning profiles including them.`,
+
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          listIdentities,
+}
+
+func init() {
+	RootCmd.AddCommand(identitiesCmd)
+	identitiesCmd.AddCommand(identitiesListCmd)
+
+	identitiesListCmd.Flags().StringVar(&paramOutputFormat, "format", outputFormatText, `Output format. Valid values: "text", "json".`)
+}
+
+func listIdentities(_ *cobra.Command, _ []string) error {
+	if paramOutputFormat != outputFormatText && paramOutputFormat != outputFormatJSON {
+		return fmt.Errorf("invalid value for format flag: %s. Valid values: '%s', '%s'", paramOutputFormat, outputFormatText, outputFormatJSON)
+	}
+
+	identities, err := codesign.InstalledKeychainIdentities()
+	if err != nil {
+		return fmt.Errorf("failed to list code signing identities, error: %s", err)
+	}
+
+	var profiles []profileutil.ProvisioningProfileInfoModel
+	for _, profileType := range []profileutil.ProfileType{profileutil.ProfileTypeIos, profileutil.ProfileTypeMacOs} {
+		installedProfiles, err := profileutil.InstalledProvisioningProfileInfos(profileType)
+		if err != nil {
+			return fmt.Errorf("failed to list installed provisioning profiles, error: %s", err)
+		}
+		profiles = append(profiles, installedProfiles...)
+	}
+
+	infos := codesign.DescribeIdentities(identities, profiles, time.Now())
+
+	if paramOutputFormat == outputFormatJSON {
+		if infos == nil {
+			infos = []codesign.IdentityInfo{}
+		}
+
+		data, err := json.MarshalIndent(infos, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal identities, error: %s", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(infos) == 0 {
+		log.Warnf("No code signing identities found in the keychain")
+		return nil
+	}
+
+	for _, kind := range []string{codesign.IdentityKindIOS, codesign.IdentityKindMacOS, codesign.IdentityKindInstaller, ""} {
+		var kindInfos []codesign.IdentityInfo
+		for _, info := range infos {
+			if kind == "" && len(info.Kinds) == 0 || kind != "" && sliceutil.IsStringInSlice(kind, info.Kinds) {
+				kindInfos = append(kindInfos, info)
+			}
+		}
+		if len(kindInfos) == 0 {
+			continue
+		}
+
+		title := kind + " certificates"
+		if kind == "" {
+			title = "Other certificates"
+		}
+
+		fmt.Println()
+		log.Infof("%s (%d)", title, len(kindInfos))
+		for _, info := range kindInfos {
+			printIdentityInfo(info)
+		}
+	}
+
+	return nil
+}
+
+func printIdentityInfo(info codesign.IdentityInfo) {
+	status := colorstring.Green("used")
+	if len(info.FilterReasons) > 0 {
+		status = colorstring.Red("filtered out")
+	}
+
+	privateKey := "yes"
+	if !info.HasPrivateKey {
+		privateKey = colorstring.Yellow("no")
+	}
+
+	fmt.Printf("- %s [%s] - %s\n", info.CommonName, info.Serial, status)
+	fmt.Printf("  team: %s (%s)\n", info.TeamName, info.TeamID)
+	fmt.Printf("  SHA1: %s\n", strings.ToUpper(info.SHA1Fingerprint))
+	fmt.Printf("  expires: %s\n", info.ExpirationDate)
+	fmt.Printf("  private key: %s\n", privateKey)
+	for _, reason := range info.FilterReasons {
+		fmt.Printf("  %s %s\n", colorstring.Red("reason:"), reason)
+	}
+	if len(info.Profiles) == 0 {
+		fmt.Printf("  profiles: -\n")
+	} else {
+		fmt.Printf("  profiles:\n")
+		for _, profile := range info.Profiles {
+			fmt.Printf("    %s\n", profile)
+		}
+	}
+}
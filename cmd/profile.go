@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bitrise-io/codesigndoc/codesign"
+	"github.com/bitrise-io/go-utils/colorstring"
+	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/bitrise-io/go-xcode/certificateutil"
+	"github.com/bitrise-io/go-xcode/profileutil"
+	"github.com/spf13/cobra"
+)
+
+const (
+	outputFormatText = "text"
+	outputFormatJSON = "json"
+)
+
+// profileCmd represents the profile command.
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Provisioning profile utilities",
+	Long:  `Provisioning profile utilities`,
+}
+
+// profileInspectCmd represents the profile inspect command.
+var profileInspectCmd = &cobra.Command{
+	Use:   "inspect <file|UUID>",
+	Short: "Prints the details of a provisioning profile",
+	Long: `Prints the details of a provisioning profile.
+
+The profile can be given by the path of a .mobileprovision or .provisionprofile file,
+or by the UUID of an installed profile.`,
+	Args: cobra.ExactArgs(1),
+
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          inspectProfile,
+}
+
+var (
+	paramOutputFormat string
+)
+
+func init() {
+	RootCmd.AddCommand(profileCmd)
+	profileCmd.AddCommand(profileInspectCmd)
+
+	profileInspectCmd.Flags().StringVar(&paramOutputFormat, "format", outputFormatText, `Output format. Valid values: "text", "json".`)
+}
+
+func inspectProfile(_ *cobra.Command, args []string) error {
+	if paramOutputFormat != outputFormatText && paramOutputFormat != outputFormatJSON {
+		return fmt.Errorf("invalid value for format flag: %s. Valid values: '%s', '%s'", paramOutputFormat, outputFormatText, outputFormatJSON)
+	}
+
+	profile, err := findProfile(args[0])
+	if err != nil {
+		return err
+	}
+
+	installedCertificates, err := certificateutil.InstalledCodesigningCertificateInfos()
+	if err != nil {
+		log.Warnf("Failed to list installed code signing identities, error: %s", err)
+	}
+
+	inspection := codesign.InspectProfile(profile, installedCertificates, time.Now())
+
+	if paramOutputFormat == outputFormatJSON {
+		data, err := json.MarshalIndent(inspection, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal provisioning profile details, error: %s", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	return printProfileInspection(inspection)
+}
+
+// findProfile reads the provisioning profile from the given file,
+// or looks up the installed profile with the given UUID if no such file exists.
+func findProfile(fileOrUUID string) (profileutil.ProvisioningProfileInfoModel, error) {
+	if exist, err := pathutil.IsPathExists(fileOrUUID); err != nil {
+		return profileutil.ProvisioningProfileInfoModel{}, fmt.Errorf("failed to check if file exists at: %s, error: %s", fileOrUUID, err)
+	} else if exist {
+		profile, err := profileutil.NewProvisioningProfileInfoFromFile(fileOrUUID)
+		if err != nil {
+			return profileutil.ProvisioningProfileInfoModel{}, fmt.Errorf("failed to parse provisioning profile (%s), error: %s", fileOrUUID, err)
+		}
+		return profile, nil
+	}
+
+	profile, pth, err := profileutil.FindProvisioningProfileInfo(fileOrUUID)
+	if err != nil {
+		return profileutil.ProvisioningProfileInfoModel{}, fmt.Errorf("failed to find installed provisioning profile (%s), error: %s", fileOrUUID, err)
+	}
+	if pth == "" {
+		return profileutil.ProvisioningProfileInfoModel{}, fmt.Errorf("no provisioning profile file nor installed provisioning profile with UUID found: %s", fileOrUUID)
+	}
+	log.Debugf("Installed provisioning profile found at: %s", pth)
+
+	return profile, nil
+}
+
+func printProfileInspection(inspection codesign.ProfileInspection) error {
+	expiration := inspection.ExpirationDate.String()
+	if inspection.IsExpired {
+		expiration += colorstring.Red(" (expired)")
+	}
+
+	devices := fmt.Sprintf("%d", inspection.ProvisionedDevices)
+	if inspection.ProvisionsAllDevices {
+		devices = "all devices"
+	}
+
+	fmt.Printf("%s %s\n", colorstring.Green("name:"), inspection.Name)
+	fmt.Printf("%s %s\n", colorstring.Green("uuid:"), inspection.UUID)
+	fmt.Printf("%s %s (%s)\n", colorstring.Green("team:"), inspection.TeamName, inspection.TeamID)
+	fmt.Printf("%s %s\n", colorstring.Green("app id:"), inspection.AppID)
+	fmt.Printf("%s %s\n", colorstring.Green("bundle id:"), inspection.BundleID)
+	fmt.Printf("%s %s\n", colorstring.Green("type:"), inspection.Type)
+	fmt.Printf("%s %s\n", colorstring.Green("export method:"), inspection.ExportMethod)
+	fmt.Printf("%s %v\n", colorstring.Green("xcode managed:"), inspection.IsXcodeManaged)
+	fmt.Printf("%s %s\n", colorstring.Green("created:"), inspection.CreationDate)
+	fmt.Printf("%s %s\n", colorstring.Green("expires:"), expiration)
+	fmt.Printf("%s %s\n", colorstring.Green("provisioned devices:"), devices)
+
+	fmt.Printf("%s\n", colorstring.Green("certificates:"))
+	for _, certificate := range inspection.Certificates {
+		installed := colorstring.Yellow("not installed")
+		if certificate.IsInstalled {
+			installed = "installed"
+		}
+		fmt.Printf("- %s [%s] SHA1: %s, expires: %s, %s\n", certificate.CommonName, certificate.Serial, certificate.SHA1Fingerprint, certificate.ExpirationDate, installed)
+	}
+
+	fmt.Printf("%s\n", colorstring.Green("entitlements:"))
+	var keys []string
+	for key := range inspection.Entitlements {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value, err := json.MarshalIndent(inspection.Entitlements[key], "  ", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal entitlement (%s), error: %s", key, err)
+		}
+		fmt.Printf("  %s: %s\n", key, strings.TrimSpace(string(value)))
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/bitrise-io/codesigndoc/bitriseio"
+	"github.com/bitrise-io/codesigndoc/bitriseio/bitrise"
+	"github.com/bitrise-io/codesigndoc/codesign"
+	"github.com/bitrise-io/go-utils/colorstring"
+	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/spf13/cobra"
+)
+
+// remoteCmd represents the remote command.
+var remoteCmd = &cobra.Command{
+	Use:   "remote",
+	Short: "Manages the code signing files uploaded to Bitrise",
+	Long:  `Manages the code signing files uploaded to Bitrise`,
+}
+
+// remotePullCmd represents the remote pull command.
+var remotePullCmd = &cobra.Command{
+	Use:   "pull",
+	Short: "Downloads the code signing files of a Bitrise app",
+	Long: `Downloads the code signing files of a Bitrise app.
+
+Downloads every certificate (decrypted with the stored passphrase) and provisioning profile uploaded to the app,
+and writes them to the output directory, or installs them with --install:
+the identities are imported into the default keychain and the provisioning profiles are copied
+into the provisioning profiles directory.`,
+
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          pullRemote,
+}
+
+// remoteCopyCmd represents the remote copy command.
+var remoteCopyCmd = &cobra.Command{
+	Use:   "copy",
+	Short: "Copies the code signing files of a Bitrise app to another app",
+	Long: `Copies the code signing files of a Bitrise app to another app.
+
+Copies every certificate and provisioning profile uploaded to the --from app, or the ones given by --file,
+to the --to app. The certificates and provisioning profiles already uploaded to the --to app are skipped,
+compared by certificate serial and provisioning profile UUID. The protected files can not be downloaded, so they are skipped too.`,
+
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          copyRemote,
+}
+
+var (
+	paramPullAppSlug    string
+	paramPullAuthToken  string
+	paramOutputDir      string
+	paramInstall        bool
+	paramWritePasswords bool
+	paramFromApp        string
+	paramToApp          string
+	paramCopyFiles      []string
+	paramCopyAuthToken  string
+	paramCopyProtected  bool
+)
+
+func init() {
+	RootCmd.AddCommand(remoteCmd)
+	remoteCmd.AddCommand(remotePullCmd)
+
+	remotePullCmd.Flags().StringVar(&paramPullAppSlug, appSlugFlag, "", "Bitrise app slug, the code signing files of the app are downloaded.")
+	remotePullCmd.Flags().StringVar(&paramPullAuthToken, authTokenFlag, "", "Bitrise personal access token. By default codesigndoc will ask for it interactively.")
+	remotePullCmd.Flags().StringVar(&paramOutputDir, "output-dir", "./codesigndoc_exports", "Directory to write the downloaded code signing files to.")
+	remotePullCmd.Flags().BoolVar(&paramInstall, "install", false, "Install the downloaded code signing files instead of writing them to the output directory.")
+	remotePullCmd.Flags().BoolVar(&paramWritePasswords, "write-passwords", false, "Write the stored passphrase of every downloaded .p12 file next to it, into a <name>.p12.password file, as plain text.")
+
+	remoteCmd.AddCommand(remoteCopyCmd)
+	remoteCopyCmd.Flags().StringVar(&paramFromApp, "from", "", "Slug of the Bitrise app to copy the code signing files from.")
+	remoteCopyCmd.Flags().StringVar(&paramToApp, "to", "", "Slug of the Bitrise app to copy the code signing files to.")
+	remoteCopyCmd.Flags().StringArrayVar(&paramCopyFiles, "file", nil, "File name or slug of an uploaded certificate or provisioning profile to copy, can be specified multiple times. Defaults to every file.")
+	remoteCopyCmd.Flags().StringVar(&paramCopyAuthToken, authTokenFlag, "", "Bitrise personal access token, with access to both apps. By default codesigndoc will ask for it interactively.")
+	remoteCopyCmd.Flags().BoolVar(&paramCopyProtected, "protected", false, `Upload every copied file as protected. The files protected on the source app are protected on the target app too.`)
+}
+
+func pullRemote(_ *cobra.Command, _ []string) error {
+	if paramPullAppSlug == "" {
+		return fmt.Errorf("the %s flag is required", appSlugFlag)
+	}
+
+	client, err := remoteClient(paramPullAuthToken, bitriseio.AppSelector{Slug: paramPullAppSlug})
+	if err != nil {
+		return err
+	}
+
+	files, err := bitriseio.DownloadCodesigningFiles(client)
+	if err != nil {
+		return err
+	}
+	identities, profiles := files.Identities, files.ProvisioningProfiles
+
+	fmt.Println()
+	if len(identities) == 0 && len(profiles) == 0 {
+		log.Warnf("No downloadable code signing files uploaded to the app")
+		printSkippedProtectedFiles(files)
+		return nil
+	}
+
+	if paramInstall {
+		log.Infof("Installing code signing files...")
+		if err := codesign.InstallCodesigningFiles(identities, profiles); err != nil {
+			return err
+		}
+
+		fmt.Println()
+		log.Successf("%d identities file(s) and %d provisioning profile(s) installed", len(identities), len(profiles))
+		printSkippedProtectedFiles(files)
+		return nil
+	}
+
+	absOutputDirPath, err := pathutil.AbsPath(paramOutputDir)
+	if err != nil {
+		return fmt.Errorf("failed to determine absolute path of output dir: %s", paramOutputDir)
+	}
+
+	log.Infof("Writing code signing files...")
+	if err := codesign.WriteDownloadedFiles(identities, profiles, absOutputDirPath, paramWritePasswords); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	log.Successf("%d identities file(s) and %d provisioning profile(s) written to: %s", len(identities), len(profiles), colorstring.Blue(absOutputDirPath))
+	if !paramWritePasswords && len(identities) > 0 {
+		log.Printf("The .p12 files are encrypted with their stored passphrase, use --write-passwords to write it next to them")
+	}
+	printSkippedProtectedFiles(files)
+	return nil
+}
+
+// printSkippedProtectedFiles prints the number of the protected files, which were skipped as they can not be downloaded.
+func printSkippedProtectedFiles(files bitriseio.DownloadedFiles) {
+	if files.ProtectedIdentities > 0 || files.ProtectedProvisioningProfiles > 0 {
+		log.Printf("Skipped %d protected identities file(s) and %d protected provisioning profile(s)", files.ProtectedIdentities, files.ProtectedProvisioningProfiles)
+	}
+}
+
+func copyRemote(_ *cobra.Command, _ []string) error {
+	if paramFromApp == "" || paramToApp == "" {
+		return fmt.Errorf("both the --from and --to flags are required")
+	}
+	if paramFromApp == paramToApp {
+		return fmt.Errorf("the --from and --to apps are the same: %s", paramFromApp)
+	}
+
+	accessToken := paramCopyAuthToken
+	if accessToken == "" {
+		var err error
+		if accessToken, err = bitriseio.AskAccessToken(); err != nil {
+			return err
+		}
+	}
+
+	source, err := bitriseio.NewConfigClient(accessToken, bitriseio.AppSelector{Slug: paramFromApp})
+	if err != nil {
+		return err
+	}
+	target, err := bitriseio.NewConfigClient(accessToken, bitriseio.AppSelector{Slug: paramToApp})
+	if err != nil {
+		return err
+	}
+
+	report, err := codesign.CopyCodesigningFiles(source, target, paramCopyFiles, paramCopyProtected)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	log.Successf("Copied %d identities file(s) and %d provisioning profile(s) from %s to %s", report.IdentitiesCopied, report.ProvisioningProfilesCopied, paramFromApp, paramToApp)
+	if report.IdentitiesSkipped > 0 || report.ProvisioningProfilesSkipped > 0 {
+		log.Printf("Skipped %d identities file(s) and %d provisioning profile(s)", report.IdentitiesSkipped, report.ProvisioningProfilesSkipped)
+	}
+	return nil
+}
+
+// remoteClient returns a bitrise client for the app selected by the selector,
+// the access token is asked if it is not given.
+func remoteClient(accessToken string, selector bitriseio.AppSelector) (bitrise.API, error) {
+	if accessToken != "" {
+		return bitriseio.NewConfigClient(accessToken, selector)
+	}
+	return bitriseio.GetInteractiveConfigClient(selector)
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -34,10 +35,26 @@ Xcode Archive and IPA export or Xcode Build For Testing action.`,
 func Execute() {
 	if err := RootCmd.Execute(); err != nil {
 		fmt.Println(err)
+
+		var exitCodeErr ExitCodeError
+		if errors.As(err, &exitCodeErr) {
+			os.Exit(exitCodeErr.code)
+		}
 		os.Exit(-1)
 	}
 }
 
+// ExitCodeError is returned by the commands which report their result with a specific exit code.
+type ExitCodeError struct {
+	code int
+	msg  string
+}
+
+// Error ...
+func (e ExitCodeError) Error() string {
+	return e.msg
+}
+
 func init() {
 	RootCmd.PersistentFlags().BoolVarP(&enableVerboseLog, "verbose", "v", false, "Enable verbose logging")
 }
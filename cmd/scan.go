@@ -2,18 +2,25 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/bitrise-io/codesigndoc/bitriseio"
 	"github.com/bitrise-io/codesigndoc/codesign"
 	"github.com/bitrise-io/go-utils/colorstring"
 	"github.com/bitrise-io/go-utils/command"
+	"github.com/bitrise-io/go-utils/fileutil"
 	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-io/go-xcode/profileutil"
 	"github.com/spf13/cobra"
 )
 
 const (
-	appSlugFlag    = "app-slug"
-	authTokenFlag  = "auth-token"
-	writeFilesFlag = "write-files"
+	appSlugFlag      = "app-slug"
+	appTitleFlag     = "app-title"
+	repoURLFlag      = "repo-url"
+	authTokenFlag    = "auth-token"
+	writeFilesFlag   = "write-files"
+	selectPolicyFlag = "select-policy"
 )
 
 // scanCmd represents the scan command.
@@ -42,11 +49,23 @@ and exports the required code signing files.`,
 				return fmt.Errorf("invalid value for %s flag. Valid values: 'always', 'fallback', 'disable'", writeFilesFlag)
 			}
 		}
+		policy, err := codesign.ParseSelectPolicy(cmd.Flag(selectPolicyFlag).Value.String())
+		if err != nil {
+			return fmt.Errorf("invalid value for %s flag: %s", selectPolicyFlag, err)
+		}
+		selectPolicy = policy
+
 		appSlug := cmd.Flag(appSlugFlag).Value.String()
+		appTitle := cmd.Flag(appTitleFlag).Value.String()
+		repoURL := cmd.Flag(repoURLFlag).Value.String()
 		authToken := cmd.Flag(authTokenFlag).Value.String()
+		if appSlug != "" && (appTitle != "" || repoURL != "") {
+			return fmt.Errorf("flag %s can not be used together with %s and %s", appSlugFlag, appTitleFlag, repoURLFlag)
+		}
+		isAppSet := appSlug != "" || appTitle != "" || repoURL != ""
 		if appSlug != "" && authToken == "" ||
-			appSlug == "" && authToken != "" {
-			return fmt.Errorf("both or none flags %s and %s are required to be set", appSlugFlag, authTokenFlag)
+			!isAppSet && authToken != "" {
+			return fmt.Errorf("both or none flags %s (or %s, %s) and %s are required to be set", appSlugFlag, appTitleFlag, repoURLFlag, authTokenFlag)
 		}
 		return nil
 	},
@@ -56,9 +75,20 @@ var (
 	isAskForPassword bool
 	certificatesOnly bool
 	writeFiles       codesign.WriteFilesLevel
+	selectPolicy     codesign.SelectPolicy
+	teamID           string
+	deviceUDIDs      string
+	deviceUDIDsFile  string
+
+	paramGenerateProject bool
 
 	personalAccessToken string
 	appSlug             string
+	appTitle            string
+	appRepoURL          string
+	appFilter           bitriseio.AppFilter
+	isProtected         bool
+	isExposed           bool
 )
 
 func init() {
@@ -69,11 +99,28 @@ func init() {
 - always: Writes artifacts in every case.
 - fallback: Does not write artifacts if the automatic upload option is chosen interactively or by providing the auth-token and app-slug flag. Writes build log only on failure.
 - disabled: Do not write any files to the export directory.`)
+	scanCmd.PersistentFlags().String(selectPolicyFlag, "", `Select the certificate and provisioning profile automatically if more than one qualifies, instead of asking. The decision and the rejected alternatives are logged. Valid values: "newest-expiry", "prefer-manual", "prefer-xcode-managed", "team=<ID>".
+- newest-expiry: Selects the one expiring last.
+- prefer-manual: Prefers manually managed profiles, then the one expiring last.
+- prefer-xcode-managed: Prefers Xcode managed profiles, then the one expiring last.
+- team=<ID>: Selects from the given team's certificates and profiles only, then the one expiring last. Fails if a single qualifying certificate or profile belongs to another team.`)
+	scanCmd.PersistentFlags().StringVar(&teamID, "team-id", "", "Collect the certificates, installer certificates and provisioning profiles of the given Apple Developer Team (ID) only.")
+	scanCmd.PersistentFlags().StringVar(&deviceUDIDs, "device-udids", "", "Comma separated list of device UDIDs, the collected development and ad-hoc provisioning profiles are checked to include each of them.")
+	scanCmd.PersistentFlags().StringVar(&deviceUDIDsFile, "device-udids-file", "", `Path of a device list file, the collected development and ad-hoc provisioning profiles are checked to include each of its devices.
+The file lists a UDID per line, the device list file exported from the Apple Developer Portal can be used too.`)
+	scanCmd.PersistentFlags().BoolVar(&paramGenerateProject, "generate-project", false, "Generate the Xcode project with XcodeGen (project.yml) or Tuist (Project.swift) before scanning, if the directory has a manifest.")
 	// Flags used to automatically upload artifacts.
 	scanCmd.PersistentFlags().StringVar(&personalAccessToken, authTokenFlag, "", `Bitrise personal access token. By default codesigndoc will ask for it interactively.
 Will upload codesigning files automatically if provided. Requires the app-slug parameter to be also set.`)
 	scanCmd.PersistentFlags().StringVar(&appSlug, appSlugFlag, "", `Bitrise app slug. By default codesigndoc will ask for it interactively.
 Will upload codesigning files automatically if provided. Requires the auth-token parameter to be also set.`)
+	scanCmd.PersistentFlags().StringVar(&appTitle, appTitleFlag, "", `Title of the Bitrise app to upload to, instead of the app slug. Fails if more than one app has the title, use it together with the repo-url flag or the app slug then.`)
+	scanCmd.PersistentFlags().StringVar(&appRepoURL, repoURLFlag, "", `Repository URL of the Bitrise app to upload to, instead of the app slug. Fails if more than one app has the repository URL.`)
+	scanCmd.PersistentFlags().StringVar(&appFilter.Owner, "app-owner", "", "Offer the Bitrise apps of the given owner (user or workspace name or slug) only, when selecting the app interactively.")
+	scanCmd.PersistentFlags().StringVar(&appFilter.ProjectType, "app-project-type", "", `Offer the Bitrise apps of the given project type (i.e. "ios", "macos", "flutter") only, when selecting the app interactively.`)
+	scanCmd.PersistentFlags().BoolVar(&appFilter.IncludeDisabled, "include-disabled-apps", false, "Offer the disabled Bitrise apps too, when selecting the app interactively.")
+	scanCmd.PersistentFlags().BoolVar(&isProtected, "protected", false, `Upload the codesigning files to Bitrise as protected, protected files can not be downloaded or modified.`)
+	scanCmd.PersistentFlags().BoolVar(&isExposed, "expose", false, "Expose the codesigning files uploaded to Bitrise to pull request builds.")
 }
 
 // Tool ...
@@ -127,6 +174,49 @@ with as many details & logs as you can share!
 `
 }
 
+// parseDeviceUDIDs returns the device UDIDs set by the --device-udids and --device-udids-file flags.
+func parseDeviceUDIDs() ([]string, error) {
+	udids := codesign.ParseDeviceUDIDs(strings.Replace(deviceUDIDs, ",", "\n", -1))
+
+	if deviceUDIDsFile != "" {
+		content, err := fileutil.ReadStringFromFile(deviceUDIDsFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read device list file (%s), error: %s", deviceUDIDsFile, err)
+		}
+		udids = append(udids, codesign.ParseDeviceUDIDs(content)...)
+	}
+
+	if len(udids) > 0 && certificatesOnly {
+		return nil, fmt.Errorf("the --device-udids and --device-udids-file flags can not be used together with the --certs-only flag")
+	}
+	return udids, nil
+}
+
+// checkProfileDevices prints the development and ad-hoc profiles missing any of the given devices.
+func checkProfileDevices(profiles []profileutil.ProvisioningProfileInfoModel, udids []string) {
+	if len(udids) == 0 {
+		return
+	}
+
+	fmt.Println()
+	log.Infof("Checking the development and ad-hoc provisioning profiles for %d devices", len(udids))
+
+	reports := codesign.CheckProfileDevices(profiles, udids)
+	if len(reports) == 0 {
+		log.Donef("Every development and ad-hoc provisioning profile includes the given devices.")
+		return
+	}
+
+	for _, report := range reports {
+		log.Errorf("🚨  %s (%s, %s) is missing %d devices:", report.Profile.Name, report.Profile.ExportType, report.Profile.UUID, len(report.MissingDevices))
+		for _, udid := range report.MissingDevices {
+			fmt.Printf("- %s\n", udid)
+		}
+	}
+	log.Warnf("Builds signed with these profiles can not be installed on the missing devices.")
+	log.Warnf("Register the devices on the Apple Developer Portal and regenerate the profiles, then run codesigndoc again.")
+}
+
 func printFinished(exportResult codesign.ExportReport, absOutputDir string) {
 	if exportResult.CodesignFilesWritten {
 		fmt.Println()
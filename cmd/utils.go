@@ -6,6 +6,7 @@ import (
 	"path"
 
 	"github.com/bitrise-io/bitrise-init/scanners/ios"
+	"github.com/bitrise-io/codesigndoc/projectmanifest"
 	"github.com/bitrise-io/go-utils/colorstring"
 	"github.com/bitrise-io/go-utils/log"
 	"github.com/bitrise-io/go-utils/pathutil"
@@ -46,6 +47,10 @@ func scanForProjectFiles() ([]string, error) {
 // findProject scans the directory for Xcode Project (.xcworkspace / .xcodeproj) file,
 // if can't find any, ask the user to drag-and-drop the file.
 func findXcodeProject() (string, error) {
+	if err := generateProjectFromManifest(); err != nil {
+		return "", err
+	}
+
 	var projpth string
 
 	projPaths, err := scanForProjectFiles()
@@ -78,3 +83,38 @@ the one you usually open in Xcode, then hit Enter.
 
 	return projpth, nil
 }
+
+// generateProjectFromManifest generates the Xcode project, if the current directory has an XcodeGen (project.yml)
+// or Tuist (Project.swift) manifest and the --generate-project flag is set.
+// If the project is not generated yet, the user is asked whether to generate it.
+func generateProjectFromManifest() error {
+	searchDir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	manifest, found := projectmanifest.Find(searchDir)
+	if !found {
+		return nil
+	}
+	log.Printf("Found %s manifest: %s", manifest.Kind, path.Base(manifest.Path))
+
+	generate := paramGenerateProject
+	if !generate {
+		if _, err := scanForProjectFiles(); err == nil {
+			log.Warnf("The Xcode project might be out of date, use the --generate-project flag to generate it before scanning.")
+			return nil
+		}
+
+		fmt.Println()
+		if generate, err = goinp.AskForBoolWithDefault(fmt.Sprintf("The Xcode project is not generated yet, generate it with %s?", manifest.Kind), true); err != nil {
+			return fmt.Errorf("failed to read input: %s", err)
+		}
+		if !generate {
+			return nil
+		}
+	}
+
+	log.Infof("Generating the Xcode project with %s", manifest.Kind)
+	return manifest.Generate()
+}
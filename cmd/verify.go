@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bitrise-io/codesigndoc/codesign"
+	"github.com/bitrise-io/codesigndoc/codesigndoc"
+	"github.com/bitrise-io/go-utils/colorstring"
+	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/spf13/cobra"
+)
+
+// verifyCmd represents the verify command.
+var verifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verifies that an export directory holds every code signing file required for the given export methods",
+	Long: `Verifies that an export directory holds every code signing file required for the given export methods.
+
+Loads the certificates of the .p12 files and the provisioning profiles from a previous export (i.e. ./codesigndoc_exports),
+and checks that every bundle ID of an archive (--archive) or of an Xcode project's scheme (--file and --scheme)
+is covered by a provisioning profile of each export method, whose certificate is included in the .p12 files.
+Fails if any of the export methods is not covered, so it can be used before uploading or committing the code signing files.`,
+
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          verifyExports,
+}
+
+var (
+	paramVerifyExportDir       string
+	paramVerifyP12Password     string
+	paramVerifyArchivePath     string
+	paramVerifyProjectFilePath string
+	paramVerifyScheme          string
+	paramVerifyExportMethod    string
+)
+
+func init() {
+	RootCmd.AddCommand(verifyCmd)
+
+	verifyCmd.Flags().StringVar(&paramVerifyExportDir, "exports", "", "Export directory of a previous scan, holding the .p12 and provisioning profile files (i.e. ./codesigndoc_exports).")
+	verifyCmd.Flags().StringVar(&paramVerifyP12Password, "p12-password", "", "Passphrase of the .p12 files of the export directory.")
+	verifyCmd.Flags().StringVar(&paramVerifyArchivePath, "archive", "", "Path of the .xcarchive to verify the code signing files against.")
+	verifyCmd.Flags().StringVar(&paramVerifyProjectFilePath, "file", "", "Xcode Project/Workspace file path to verify the code signing files against, the project is read statically without archiving.")
+	verifyCmd.Flags().StringVar(&paramVerifyScheme, "scheme", "", "Xcode Scheme, required if the --file flag is set.")
+	verifyCmd.Flags().StringVar(&paramVerifyExportMethod, "export-method", "", `Comma separated list of export methods to verify, i.e "development,ad-hoc,app-store".`)
+}
+
+func verifyExports(_ *cobra.Command, _ []string) error {
+	if paramVerifyExportDir == "" {
+		return fmt.Errorf("the --exports flag is required")
+	}
+	if paramVerifyArchivePath == "" && paramVerifyProjectFilePath == "" || paramVerifyArchivePath != "" && paramVerifyProjectFilePath != "" {
+		return fmt.Errorf("exactly one of the --archive and --file flags is required")
+	}
+	if paramVerifyProjectFilePath != "" && paramVerifyScheme == "" {
+		return fmt.Errorf("the --scheme flag is required if the --file flag is set")
+	}
+
+	exportMethods, err := parseExportMethods(paramVerifyExportMethod)
+	if err != nil {
+		return err
+	}
+	if len(exportMethods) == 0 {
+		return fmt.Errorf("the --export-method flag is required")
+	}
+
+	absExportDir, err := pathutil.AbsPath(paramVerifyExportDir)
+	if err != nil {
+		return fmt.Errorf("failed to determine absolute path of export dir: %s", paramVerifyExportDir)
+	}
+
+	certificates, profiles, err := codesign.ReadExportDir(absExportDir, paramVerifyP12Password)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	log.Infof("Code signing files of the export directory (%s):", absExportDir)
+	fmt.Printf("%d certificates, %d provisioning profiles\n", len(certificates), len(profiles))
+
+	var archive codesigndoc.Archive
+	var isMacArchive bool
+	if paramVerifyArchivePath != "" {
+		archive, isMacArchive, err = codesigndoc.OpenArchive(paramVerifyArchivePath)
+	} else {
+		archive, isMacArchive, err = codesigndoc.NewProjectArchive(paramVerifyProjectFilePath, paramVerifyScheme)
+	}
+	if err != nil {
+		return err
+	}
+
+	verifications, err := codesigndoc.VerifyCodesignFiles(archive, isMacArchive, certificates, profiles, exportMethods)
+	if err != nil {
+		return err
+	}
+
+	var failedExportMethods []string
+	for _, verification := range verifications {
+		fmt.Println()
+		if verification.Covered() {
+			log.Donef("%s export: covered", verification.ExportMethod)
+			for _, certificate := range verification.Certificates {
+				fmt.Printf("- %s [%s]\n", certificate.CommonName, certificate.Serial)
+			}
+			continue
+		}
+
+		failedExportMethods = append(failedExportMethods, verification.ExportMethod)
+		log.Errorf("%s export: not covered", verification.ExportMethod)
+		if len(verification.UncoveredBundleIDs) == 0 {
+			fmt.Println("Every bundle ID has a provisioning profile, but none of the certificates is included in a profile of each bundle ID.")
+		}
+		for _, bundleID := range verification.UncoveredBundleIDs {
+			fmt.Printf("- %s %s\n", bundleID, colorstring.Red("no matching provisioning profile with an exported certificate"))
+		}
+	}
+
+	if len(failedExportMethods) > 0 {
+		return errors.New(colorstring.Redf("the export directory is missing code signing files for the %s export methods", strings.Join(failedExportMethods, ", ")))
+	}
+
+	fmt.Println()
+	log.Successf("The export directory holds every code signing file required for the %s export methods.", strings.Join(exportMethods, ", "))
+	return nil
+}
@@ -8,12 +8,16 @@ import (
 
 	"github.com/bitrise-io/codesigndoc/codesign"
 	"github.com/bitrise-io/codesigndoc/codesigndoc"
+	"github.com/bitrise-io/codesigndoc/codesigndocuitests"
 	"github.com/bitrise-io/codesigndoc/utility"
 	"github.com/bitrise-io/codesigndoc/xcode"
+	"github.com/bitrise-io/codesigndoc/xcodeuitest"
 	"github.com/bitrise-io/go-utils/colorstring"
 	"github.com/bitrise-io/go-utils/fileutil"
 	"github.com/bitrise-io/go-utils/log"
 	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/bitrise-io/go-xcode/certificateutil"
+	"github.com/bitrise-io/go-xcode/profileutil"
 	"github.com/bitrise-io/goinp/goinp"
 	"github.com/spf13/cobra"
 )
@@ -35,6 +39,18 @@ var (
 	paramXcodeScheme          string
 	paramXcodebuildSDK        string
 	paramXcodeDestination     string
+	paramExportMethod         string
+	paramWithUITests          bool
+
+	paramXcodeConfiguration            string
+	paramXcodeXCConfig                 string
+	paramXcodeBuildSettings            []string
+	paramXcodeAllowProvisioningUpdates bool
+	paramXcodeDerivedDataPath          string
+
+	paramXcodeAllSchemes  bool
+	paramXcodeAllProjects bool
+	paramXcodeStatic      bool
 )
 
 func init() {
@@ -44,6 +60,92 @@ func init() {
 	xcodeCmd.Flags().StringVar(&paramXcodeScheme, "scheme", "", "Xcode Scheme")
 	xcodeCmd.Flags().StringVar(&paramXcodebuildSDK, "xcodebuild-sdk", "", "xcodebuild -sdk param. If a value is specified for this flag it'll be passed to xcodebuild as the value of the -sdk flag. For more info about the values please see xcodebuild's -sdk flag docs. Example value: iphoneos")
 	xcodeCmd.Flags().StringVar(&paramXcodeDestination, "xcodebuild-destination", "", "The xcodebuild -destination option takes as its argument a destination specifier describing the device (or devices) to use as a destination i.e `generic/platform=iOS`. If a value is specified for this flag it'll be passed to xcodebuild.")
+	xcodeCmd.Flags().StringVar(&paramExportMethod, "export-method", "", `Comma separated list of export methods to collect the code signing files for, i.e "development,ad-hoc,app-store".
+If provided, the export methods are not asked interactively. The certificate and latest profile are selected automatically if there is only one candidate, the scan fails if there are multiple.`)
+	xcodeCmd.Flags().StringVar(&paramXcodeConfiguration, "configuration", "", "Build configuration to archive with, i.e Staging. Defaults to the build configuration of the scheme's archive action.")
+	xcodeCmd.Flags().StringVar(&paramXcodeXCConfig, "xcconfig", "", "Path of an xcconfig file, passed to xcodebuild as the value of the -xcconfig flag.")
+	xcodeCmd.Flags().StringArrayVar(&paramXcodeBuildSettings, "build-setting", nil, "Additional build setting passed to xcodebuild in KEY=VALUE format, i.e `--build-setting SWIFT_ACTIVE_COMPILATION_CONDITIONS=STAGING`. Can be specified multiple times.")
+	xcodeCmd.Flags().BoolVar(&paramXcodeAllowProvisioningUpdates, "allow-provisioning-updates", false, "Pass the -allowProvisioningUpdates flag to xcodebuild, allowing it to create and update the provisioning profiles of automatically signed targets.")
+	xcodeCmd.Flags().StringVar(&paramXcodeDerivedDataPath, "derived-data-path", "", "Derived data path, passed to xcodebuild as the value of the -derivedDataPath flag.")
+	xcodeCmd.Flags().BoolVar(&paramXcodeAllSchemes, "all-schemes", false, "Archive every archivable scheme of the project, and export and upload the code signing files of every scheme together.")
+	xcodeCmd.Flags().BoolVar(&paramXcodeAllProjects, "all-projects", false, "Archive every archivable scheme of every workspace and standalone project found in the current directory, and export and upload the code signing files of every scheme together.")
+	xcodeCmd.Flags().BoolVar(&paramXcodeStatic, "static", false, "Read the code signing settings (team, signing style, provisioning profile specifiers) of the XcodeGen manifest (project.yml) and print them, without generating the project or archiving.")
+	xcodeCmd.Flags().BoolVar(&paramWithUITests, "with-uitests", false, "Run a build-for-testing after the archive and collect the code signing files of the UI test targets too. The files of the app and the UI tests are exported and uploaded together.")
+}
+
+// xcodeScanOptions are the options of an Xcode project scan,
+// set by the flags of the scan xcode command or by the cross-platform scan commands.
+type xcodeScanOptions struct {
+	ProjectPath  string
+	Scheme       string
+	SDK          string
+	Destination  string
+	ExportMethod string
+	WithUITests  bool
+
+	Configuration            string
+	XCConfig                 string
+	BuildSettings            []string
+	AllowProvisioningUpdates bool
+	DerivedDataPath          string
+
+	AllSchemes  bool
+	AllProjects bool
+	Static      bool
+
+	// ProvisioningUpdatesFlag is the flag of the command setting AllowProvisioningUpdates,
+	// suggested by the fixes of the code signing failures. Empty if the command has no such flag.
+	ProvisioningUpdatesFlag string
+}
+
+// xcodeFlagOptions returns the scan options set by the flags of the scan xcode command.
+func xcodeFlagOptions() xcodeScanOptions {
+	return xcodeScanOptions{
+		ProjectPath:              paramXcodeProjectFilePath,
+		Scheme:                   paramXcodeScheme,
+		SDK:                      paramXcodebuildSDK,
+		Destination:              paramXcodeDestination,
+		ExportMethod:             paramExportMethod,
+		WithUITests:              paramWithUITests,
+		Configuration:            paramXcodeConfiguration,
+		XCConfig:                 paramXcodeXCConfig,
+		BuildSettings:            paramXcodeBuildSettings,
+		AllowProvisioningUpdates: paramXcodeAllowProvisioningUpdates,
+		DerivedDataPath:          paramXcodeDerivedDataPath,
+		AllSchemes:               paramXcodeAllSchemes,
+		AllProjects:              paramXcodeAllProjects,
+		Static:                   paramXcodeStatic,
+		ProvisioningUpdatesFlag:  "--allow-provisioning-updates",
+	}
+}
+
+// parseExportMethods returns the export methods of the comma separated list set by an --export-method flag.
+func parseExportMethods(exportMethods string) ([]string, error) {
+	var methods []string
+	for _, method := range strings.Split(exportMethods, ",") {
+		method = strings.TrimSpace(method)
+		if method == "" {
+			continue
+		}
+		if _, err := codesign.ProfileExportType(method); err != nil {
+			return nil, err
+		}
+		methods = append(methods, method)
+	}
+	return methods, nil
+}
+
+// parseBuildSettings returns the build settings set by the --build-setting flags.
+func parseBuildSettings(values []string) ([]string, error) {
+	var buildSettings []string
+	for _, buildSetting := range values {
+		buildSetting = strings.TrimSpace(buildSetting)
+		if strings.Index(buildSetting, "=") <= 0 {
+			return nil, fmt.Errorf("invalid build setting (%s), the format is KEY=VALUE", buildSetting)
+		}
+		buildSettings = append(buildSettings, buildSetting)
+	}
+	return buildSettings, nil
 }
 
 func absOutputDir() (string, error) {
@@ -57,14 +159,48 @@ func absOutputDir() (string, error) {
 }
 
 func scanXcodeProject(_ *cobra.Command, _ []string) error {
+	return scanXcode(xcodeFlagOptions())
+}
+
+// scanXcode archives the scheme of the project with the given options, then exports and uploads the required code signing files.
+func scanXcode(options xcodeScanOptions) error {
+	if options.Static {
+		return reportXcodeGenSigning(options.ProjectPath, options.Configuration)
+	}
+
 	absExportOutputDirPath, err := absOutputDir()
 	if err != nil {
 		return err
 	}
 
+	exportMethods, err := parseExportMethods(options.ExportMethod)
+	if err != nil {
+		return err
+	}
+	if len(exportMethods) > 0 && certificatesOnly {
+		return fmt.Errorf("the --export-method flag can not be used together with the --certs-only flag")
+	}
+
+	if options.WithUITests && certificatesOnly {
+		return fmt.Errorf("the --with-uitests flag can not be used together with the --certs-only flag")
+	}
+
+	udids, err := parseDeviceUDIDs()
+	if err != nil {
+		return err
+	}
+
+	if options.BuildSettings, err = parseBuildSettings(options.BuildSettings); err != nil {
+		return err
+	}
+
+	if options.AllSchemes || options.AllProjects {
+		return scanXcodeSchemes(options, exportMethods, udids, absExportOutputDirPath)
+	}
+
 	xcodeCmd := xcode.CommandModel{}
 
-	projectPath := paramXcodeProjectFilePath
+	projectPath := options.ProjectPath
 	if projectPath == "" {
 		log.Infof("Scan the directory for project files")
 		log.Warnf("You can specify the Xcode project/workspace file to scan with the --file flag.")
@@ -81,7 +217,7 @@ func scanXcodeProject(_ *cobra.Command, _ []string) error {
 	log.Debugf("projectPath: %s", projectPath)
 	xcodeCmd.ProjectFilePath = projectPath
 
-	schemeToUse := paramXcodeScheme
+	schemeToUse := options.Scheme
 	if schemeToUse == "" {
 		fmt.Println()
 		log.Printf("🔦  Scanning Schemes ...")
@@ -106,18 +242,60 @@ func scanXcodeProject(_ *cobra.Command, _ []string) error {
 
 		log.Debugf("selected scheme: %v", schemeToUse)
 	}
-	xcodeCmd.Scheme = schemeToUse
 
-	if paramXcodebuildSDK != "" {
-		xcodeCmd.SDK = paramXcodebuildSDK
+	files, err := archiveAndCollectCodesignFiles(options, projectPath, schemeToUse, exportMethods, absExportOutputDirPath, "")
+	if err != nil {
+		return err
+	}
+
+	checkProfileDevices(files.Profiles, udids)
+
+	exportResult, err := exportCodesignFiles(files.Certificates, files.Profiles, absExportOutputDirPath, func() error {
+		return codesign.WriteExportOptions(files.ExportOptions, absExportOutputDirPath)
+	})
+	if err != nil {
+		return err
+	}
+
+	printFinished(exportResult, absExportOutputDirPath)
+	return nil
+}
+
+// schemeCodesignFiles are the code signing files collected for a scheme.
+type schemeCodesignFiles struct {
+	ProjectPath   string
+	Scheme        string
+	Certificates  []certificateutil.CertificateInfoModel
+	Profiles      []profileutil.ProvisioningProfileInfoModel
+	ExportOptions []codesign.ExportOptions
+}
+
+// archiveAndCollectCodesignFiles archives the scheme and collects the code signing files required to archive and export it,
+// including the files of the UI test targets if the WithUITests option is set.
+// The logFileSuffix is appended to the names of the written build log files.
+func archiveAndCollectCodesignFiles(options xcodeScanOptions, projectPath, schemeToUse string, exportMethods []string, absExportOutputDirPath, logFileSuffix string) (schemeCodesignFiles, error) {
+	xcodeCmd := xcode.CommandModel{
+		ProjectFilePath: projectPath,
+		Scheme:          schemeToUse,
 	}
 
-	if paramXcodeDestination != "" {
-		xcodeCmd.Destination = paramXcodeDestination
+	if options.SDK != "" {
+		xcodeCmd.SDK = options.SDK
+	}
+
+	xcodeCmd.Configuration = options.Configuration
+	xcodeCmd.XCConfig = options.XCConfig
+	xcodeCmd.BuildSettings = options.BuildSettings
+	xcodeCmd.AllowProvisioningUpdates = options.AllowProvisioningUpdates
+	xcodeCmd.DerivedDataPath = options.DerivedDataPath
+
+	var err error
+	if options.Destination != "" {
+		xcodeCmd.Destination = options.Destination
 	} else {
-		project, scheme, configuration, err := utility.OpenArchivableProject(xcodeCmd.ProjectFilePath, xcodeCmd.Scheme, "")
+		project, scheme, configuration, err := utility.OpenArchivableProject(xcodeCmd.ProjectFilePath, xcodeCmd.Scheme, xcodeCmd.Configuration)
 		if err != nil {
-			return err
+			return schemeCodesignFiles{}, err
 		}
 
 		platform, err := utility.BuildableTargetPlatform(project, scheme, configuration, utility.XcodeBuild{})
@@ -132,7 +310,7 @@ func scanXcodeProject(_ *cobra.Command, _ []string) error {
 
 	writeBuildLogs := func(xcodebuildOutput string) error {
 		if writeFiles == codesign.WriteFilesAlways || writeFiles == codesign.WriteFilesFallback && err != nil { // save the xcodebuild output into a debug log file
-			xcodebuildOutputFilePath := filepath.Join(absExportOutputDirPath, "xcodebuild-output.log")
+			xcodebuildOutputFilePath := filepath.Join(absExportOutputDirPath, "xcodebuild-output"+logFileSuffix+".log")
 			if err := os.MkdirAll(absExportOutputDirPath, 0700); err != nil {
 				return fmt.Errorf("failed to create output directory, error: %s", err)
 			}
@@ -145,14 +323,72 @@ func scanXcodeProject(_ *cobra.Command, _ []string) error {
 		return nil
 	}
 
-	archivePath, err := codesigndoc.BuildXcodeArchive(xcodeCmd, writeBuildLogs)
+	archivePath, err := codesigndoc.BuildXcodeArchive(xcodeCmd, options.ProvisioningUpdatesFlag, writeBuildLogs)
 	if err != nil {
-		return ArchiveError{toolXcode, err.Error()}
+		return schemeCodesignFiles{}, ArchiveError{toolXcode, err.Error()}
 	}
 
-	certificates, profiles, err := codesigndoc.CodesigningFilesForXCodeProject(archivePath, certificatesOnly, isAskForPassword)
+	var buildForTestingPath string
+	if options.WithUITests {
+		xcodeUITestsCmd := xcodeuitest.CommandModel{
+			ProjectFilePath:          xcodeCmd.ProjectFilePath,
+			Scheme:                   xcodeCmd.Scheme,
+			SDK:                      xcodeCmd.SDK,
+			Destination:              xcodeCmd.Destination,
+			Configuration:            xcodeCmd.Configuration,
+			XCConfig:                 xcodeCmd.XCConfig,
+			BuildSettings:            xcodeCmd.BuildSettings,
+			AllowProvisioningUpdates: xcodeCmd.AllowProvisioningUpdates,
+			DerivedDataPath:          xcodeCmd.DerivedDataPath,
+		}
+
+		buildForTestingPath, err = runBuildForTesting(xcodeUITestsCmd, options.ProvisioningUpdatesFlag, absExportOutputDirPath, "xcodebuild-build-for-testing-output"+logFileSuffix+".log")
+		if err != nil {
+			return schemeCodesignFiles{}, err
+		}
+	}
+
+	// If certificatesOnly is set, CollectCodesignFiles returns an empty slice for profiles
+	certificatesToExport, profilesToExport, exportOptions, err := codesigndoc.CollectCodesignFiles(archivePath, certificatesOnly, exportMethods, selectPolicy, teamID)
 	if err != nil {
-		return err
+		return schemeCodesignFiles{}, err
+	}
+
+	if options.WithUITests {
+		platform, err := detectPlatform(xcodeCmd.ProjectFilePath, xcodeCmd.Scheme)
+		if err != nil {
+			log.Debugf("Failed to detect the platform of the scheme (%s), falling back to %s: %s", xcodeCmd.Scheme, utility.IOS, err)
+			platform = utility.IOS
+		}
+
+		fmt.Println()
+		log.Infof("Collecting the code signing files of the UI test targets")
+
+		uiTestCertificates, uiTestProfiles, err := codesigndocuitests.CollectCodesignFiles(buildForTestingPath, platform, certificatesOnly, selectPolicy, teamID)
+		if err != nil {
+			return schemeCodesignFiles{}, err
+		}
+
+		// The app and the UI test targets are usually signed with the same certificates and often with the same profiles
+		certificatesToExport = codesign.MergeCertificates(certificatesToExport, uiTestCertificates)
+		profilesToExport = codesign.MergeProfiles(profilesToExport, uiTestProfiles)
+	}
+
+	return schemeCodesignFiles{
+		ProjectPath:   projectPath,
+		Scheme:        schemeToUse,
+		Certificates:  certificatesToExport,
+		Profiles:      profilesToExport,
+		ExportOptions: exportOptions,
+	}, nil
+}
+
+// exportCodesignFiles exports the code signing files from the Keychain, then uploads and writes them.
+// The export options are written by writeExportOptions, unless writing files is disabled.
+func exportCodesignFiles(certificatesToExport []certificateutil.CertificateInfoModel, profilesToExport []profileutil.ProvisioningProfileInfoModel, absExportOutputDirPath string, writeExportOptions func() error) (codesign.ExportReport, error) {
+	certificates, profiles, err := codesign.ExportCodesigningFiles(certificatesToExport, profilesToExport, isAskForPassword)
+	if err != nil {
+		return codesign.ExportReport{}, err
 	}
 
 	exportResult, err := codesign.UploadAndWriteCodesignFiles(certificates,
@@ -164,11 +400,22 @@ func scanXcodeProject(_ *cobra.Command, _ []string) error {
 		codesign.UploadConfig{
 			PersonalAccessToken: personalAccessToken,
 			AppSlug:             appSlug,
+			AppTitle:            appTitle,
+			AppRepoURL:          appRepoURL,
+			AppFilter:           appFilter,
+			IsProtected:         isProtected,
+			IsExposed:           isExposed,
 		})
 	if err != nil {
-		return err
+		return codesign.ExportReport{}, err
 	}
 
-	printFinished(exportResult, absExportOutputDirPath)
-	return nil
+	// The export options are not uploaded, they are written unless writing files is disabled.
+	if writeFiles != codesign.WriteFilesDisabled {
+		if err := writeExportOptions(); err != nil {
+			return codesign.ExportReport{}, err
+		}
+	}
+
+	return exportResult, nil
 }
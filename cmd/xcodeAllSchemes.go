@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/bitrise-io/bitrise-init/scanners/ios"
+	"github.com/bitrise-io/codesigndoc/codesign"
+	"github.com/bitrise-io/codesigndoc/utility"
+	"github.com/bitrise-io/codesigndoc/xcode"
+	"github.com/bitrise-io/go-utils/colorstring"
+	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/bitrise-io/go-xcode/certificateutil"
+	"github.com/bitrise-io/go-xcode/profileutil"
+	"github.com/bitrise-io/go-xcode/xcodeproject/xcworkspace"
+)
+
+// xcodeScheme is a scheme of an Xcode project or workspace.
+type xcodeScheme struct {
+	ProjectPath string
+	Scheme      string
+}
+
+// name returns the name of the scheme prefixed with the name of its project, i.e. "MyApp-Release",
+// usable as a file or directory name.
+func (s xcodeScheme) name() string {
+	project := strings.TrimSuffix(filepath.Base(s.ProjectPath), filepath.Ext(s.ProjectPath))
+	return unsafeFileNameCharacters.ReplaceAllString(project+"-"+s.Scheme, "_")
+}
+
+var unsafeFileNameCharacters = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// schemeScanResult is the result of scanning a scheme in the --all-schemes and --all-projects modes.
+type schemeScanResult struct {
+	xcodeScheme
+	Files schemeCodesignFiles
+	Err   error
+}
+
+// scanXcodeSchemes archives every archivable scheme of the project (--all-schemes)
+// or of every project and workspace found in the current directory (--all-projects),
+// then exports and uploads the code signing files required by the schemes together.
+// The scan continues if a scheme fails, the failed schemes are listed in the final report.
+func scanXcodeSchemes(options xcodeScanOptions, exportMethods, udids []string, absExportOutputDirPath string) error {
+	if options.Scheme != "" {
+		return fmt.Errorf("the --scheme flag can not be used together with the --all-schemes and --all-projects flags")
+	}
+	if options.AllProjects && options.ProjectPath != "" {
+		return fmt.Errorf("the --file flag can not be used together with the --all-projects flag")
+	}
+
+	projectPaths, err := projectPathsToScan(options)
+	if err != nil {
+		return err
+	}
+
+	var schemes []xcodeScheme
+	for _, projectPath := range projectPaths {
+		fmt.Println()
+		log.Printf("🔦  Scanning Schemes of %s ...", projectPath)
+		archivable, err := archivableSchemes(projectPath, options.Configuration)
+		if err != nil {
+			return ArchiveError{toolXcode, err.Error()}
+		}
+		for _, scheme := range archivable {
+			log.Printf("- %s", scheme)
+			schemes = append(schemes, xcodeScheme{ProjectPath: projectPath, Scheme: scheme})
+		}
+	}
+	if len(schemes) == 0 {
+		return ArchiveError{toolXcode, "no archivable schemes found"}
+	}
+
+	var results []schemeScanResult
+	for i, scheme := range schemes {
+		fmt.Println()
+		log.Infof("Scanning scheme %d/%d: %s (%s)", i+1, len(schemes), scheme.Scheme, scheme.ProjectPath)
+
+		files, err := archiveAndCollectCodesignFiles(options, scheme.ProjectPath, scheme.Scheme, exportMethods, absExportOutputDirPath, "-"+scheme.name())
+		if err != nil {
+			log.Errorf("Failed to scan scheme %s: %s", scheme.Scheme, err)
+		}
+		results = append(results, schemeScanResult{xcodeScheme: scheme, Files: files, Err: err})
+	}
+
+	certificatesToExport, profilesToExport, failed := mergeSchemeScanResults(results)
+	if failed == len(results) {
+		printSchemeScanReport(results)
+		return ArchiveError{toolXcode, "every scheme failed to scan"}
+	}
+
+	checkProfileDevices(profilesToExport, udids)
+
+	exportResult, err := exportCodesignFiles(certificatesToExport, profilesToExport, absExportOutputDirPath, func() error {
+		// The export options are written per scheme, as the schemes may be exported with different profiles.
+		for _, result := range results {
+			if result.Err != nil {
+				continue
+			}
+			if err := codesign.WriteExportOptions(result.Files.ExportOptions, filepath.Join(absExportOutputDirPath, result.name())); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	printSchemeScanReport(results)
+	printFinished(exportResult, absExportOutputDirPath)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d schemes failed to scan", failed, len(results))
+	}
+	return nil
+}
+
+// mergeSchemeScanResults merges the certificates and provisioning profiles required by the successfully scanned schemes
+// without duplicates, and returns the number of the failed schemes.
+func mergeSchemeScanResults(results []schemeScanResult) ([]certificateutil.CertificateInfoModel, []profileutil.ProvisioningProfileInfoModel, int) {
+	var certificateLists [][]certificateutil.CertificateInfoModel
+	var profileLists [][]profileutil.ProvisioningProfileInfoModel
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+			continue
+		}
+		certificateLists = append(certificateLists, result.Files.Certificates)
+		profileLists = append(profileLists, result.Files.Profiles)
+	}
+	return codesign.MergeCertificates(certificateLists...), codesign.MergeProfiles(profileLists...), failed
+}
+
+// projectPathsToScan returns the project set by the --file flag or found in the current directory,
+// or every project and workspace found in the current directory if --all-projects is set.
+func projectPathsToScan(options xcodeScanOptions) ([]string, error) {
+	if options.AllProjects {
+		log.Infof("Scan the directory for project files")
+		if err := generateProjectFromManifest(); err != nil {
+			return nil, err
+		}
+
+		searchDir, err := os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		return allProjectPaths(searchDir)
+	}
+
+	if options.ProjectPath != "" {
+		return []string{options.ProjectPath}, nil
+	}
+
+	log.Infof("Scan the directory for project files")
+	log.Warnf("You can specify the Xcode project/workspace file to scan with the --file flag.")
+
+	projpth, err := findXcodeProject()
+	if err != nil {
+		return nil, err
+	}
+	return []string{strings.Trim(strings.TrimSpace(projpth), "'\"")}, nil
+}
+
+// allProjectPaths returns every workspace and every project found in the search directory,
+// except for the projects contained in one of the workspaces, as their schemes are scanned with the workspace.
+func allProjectPaths(searchDir string) ([]string, error) {
+	fileList, err := pathutil.ListPathInDirSortedByComponents(searchDir, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for files in (%s), error: %s", searchDir, err)
+	}
+
+	workspaces, err := ios.FilterRelevantWorkspaceFiles(fileList)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for workspace files, error: %s", err)
+	}
+	projects, err := ios.FilterRelevantProjectFiles(fileList)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for project files, error: %s", err)
+	}
+
+	containedProjects := map[string]bool{}
+	for _, workspacePath := range workspaces {
+		workspace, err := xcworkspace.Open(workspacePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open workspace (%s), error: %s", workspacePath, err)
+		}
+		projectPaths, err := workspace.ProjectFileLocations()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the projects of workspace (%s), error: %s", workspacePath, err)
+		}
+		for _, projectPath := range projectPaths {
+			containedProjects[filepath.Clean(projectPath)] = true
+		}
+	}
+
+	paths := workspaces
+	for _, projectPath := range projects {
+		if containedProjects[filepath.Clean(projectPath)] {
+			log.Debugf("Skipping project %s, it is scanned with its workspace", projectPath)
+			continue
+		}
+		paths = append(paths, projectPath)
+	}
+
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no project file found: %s", searchDir)
+	}
+	return paths, nil
+}
+
+// archivableSchemes returns the schemes of the project or workspace with an archivable target in the given build configuration,
+// or in the build configuration of the scheme's archive action if it is empty.
+func archivableSchemes(projectPath, configuration string) ([]string, error) {
+	schemes, err := xcode.CommandModel{ProjectFilePath: projectPath}.ScanSchemes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan Schemes of %s: %s", projectPath, err)
+	}
+	log.Debugf("schemes: %v", schemes)
+
+	var archivable []string
+	for _, scheme := range schemes {
+		if _, _, _, err := utility.OpenArchivableProject(projectPath, scheme, configuration); err != nil {
+			log.Debugf("Skipping scheme %s, it is not archivable: %s", scheme, err)
+			continue
+		}
+		archivable = append(archivable, scheme)
+	}
+	return archivable, nil
+}
+
+// printSchemeScanReport prints the certificates and provisioning profiles required by each scheme, or why its scan failed.
+func printSchemeScanReport(results []schemeScanResult) {
+	fmt.Println()
+	log.Infof("Code signing files per scheme")
+	for _, result := range results {
+		fmt.Println()
+		if result.Err != nil {
+			log.Errorf("%s (%s): failed", result.Scheme, result.ProjectPath)
+			log.Printf("  %s", result.Err)
+			continue
+		}
+
+		log.Printf("%s (%s)", colorstring.Green(result.Scheme), result.ProjectPath)
+		log.Printf("  certificates:")
+		for _, certificate := range result.Files.Certificates {
+			log.Printf("  - %s [%s]", certificate.CommonName, certificate.Serial)
+		}
+		if !certificatesOnly {
+			log.Printf("  provisioning profiles:")
+			for _, profile := range result.Files.Profiles {
+				log.Printf("  - %s (%s) [%s]", profile.Name, profile.BundleID, profile.UUID)
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bitrise-io/go-xcode/certificateutil"
+	"github.com/bitrise-io/go-xcode/profileutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXcodeScheme_name(t *testing.T) {
+	require.Equal(t, "MyApp-Release", xcodeScheme{ProjectPath: "ios/MyApp.xcworkspace", Scheme: "Release"}.name())
+	require.Equal(t, "My_App-App__Staging_", xcodeScheme{ProjectPath: "My App.xcodeproj", Scheme: "App (Staging)"}.name())
+}
+
+func TestAllProjectPaths(t *testing.T) {
+	dir := t.TempDir()
+	for _, projectDir := range []string{"App.xcodeproj", "Framework/Framework.xcodeproj", "Tools/Tools.xcodeproj", "App.xcworkspace", "Pods/Pods.xcodeproj"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, projectDir), 0700))
+	}
+	workspace := `<?xml version="1.0" encoding="UTF-8"?>
+<Workspace version = "1.0">
+   <FileRef location = "group:App.xcodeproj"></FileRef>
+   <FileRef location = "group:Framework/Framework.xcodeproj"></FileRef>
+   <FileRef location = "group:Pods/Pods.xcodeproj"></FileRef>
+</Workspace>`
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "App.xcworkspace", "contents.xcworkspacedata"), []byte(workspace), 0600))
+
+	paths, err := allProjectPaths(dir)
+	require.NoError(t, err)
+	require.Equal(t, []string{filepath.Join(dir, "App.xcworkspace"), filepath.Join(dir, "Tools", "Tools.xcodeproj")}, paths)
+
+	_, err = allProjectPaths(t.TempDir())
+	require.Error(t, err)
+}
+
+func TestMergeSchemeScanResults(t *testing.T) {
+	development := certificateutil.CertificateInfoModel{CommonName: "Apple Development: Test User (ABCD1234)", Serial: "1"}
+	distribution := certificateutil.CertificateInfoModel{CommonName: "Apple Distribution: Test Team (ABCD1234)", Serial: "2"}
+	appProfile := profileutil.ProvisioningProfileInfoModel{Name: "App", UUID: "uuid-1"}
+	extensionProfile := profileutil.ProvisioningProfileInfoModel{Name: "Extension", UUID: "uuid-2"}
+
+	results := []schemeScanResult{
+		{
+			xcodeScheme: xcodeScheme{ProjectPath: "App.xcworkspace", Scheme: "App"},
+			Files:       schemeCodesignFiles{Certificates: []certificateutil.CertificateInfoModel{development}, Profiles: []profileutil.ProvisioningProfileInfoModel{appProfile}},
+		},
+		{
+			xcodeScheme: xcodeScheme{ProjectPath: "App.xcworkspace", Scheme: "Extension"},
+			Files:       schemeCodesignFiles{Certificates: []certificateutil.CertificateInfoModel{development, distribution}, Profiles: []profileutil.ProvisioningProfileInfoModel{appProfile, extensionProfile}},
+		},
+		{
+			xcodeScheme: xcodeScheme{ProjectPath: "Tools.xcodeproj", Scheme: "Tools"},
+			Files:       schemeCodesignFiles{Certificates: []certificateutil.CertificateInfoModel{{Serial: "3"}}},
+			Err:         errors.New("archive failed"),
+		},
+	}
+
+	certificates, profiles, failed := mergeSchemeScanResults(results)
+	require.Equal(t, []certificateutil.CertificateInfoModel{development, distribution}, certificates)
+	require.Equal(t, []profileutil.ProvisioningProfileInfoModel{appProfile, extensionProfile}, profiles)
+	require.Equal(t, 1, failed)
+
+	printSchemeScanReport(results)
+}
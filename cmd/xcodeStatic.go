@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/bitrise-io/codesigndoc/projectmanifest"
+	"github.com/bitrise-io/go-utils/colorstring"
+	"github.com/bitrise-io/go-utils/log"
+)
+
+// reportXcodeGenSigning prints the code signing settings of the given XcodeGen manifest (set by the --file flag),
+// or of the one found in the current directory, without generating the project.
+// Only the settings of the given build configuration are printed, if it is not empty.
+func reportXcodeGenSigning(manifestPath, configuration string) error {
+	if manifestPath == "" {
+		searchDir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+
+		manifest, found := projectmanifest.Find(searchDir)
+		if !found {
+			return fmt.Errorf("no XcodeGen manifest (project.yml) found in %s", searchDir)
+		}
+		if manifest.Kind != projectmanifest.XcodeGen {
+			return fmt.Errorf("the --static flag supports XcodeGen manifests (project.yml) only, %s manifests can not be read without generating the project", manifest.Kind)
+		}
+		manifestPath = manifest.Path
+	} else if !projectmanifest.IsXcodeGenManifest(manifestPath) {
+		return fmt.Errorf("the --static flag supports XcodeGen manifests (project.yml) only: %s", manifestPath)
+	}
+
+	signings, err := projectmanifest.ReadXcodeGenSigning(manifestPath)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	log.Infof("Code signing settings of %s", manifestPath)
+	log.Warnf("Included specs, setting groups, target templates and build setting variables are not resolved.")
+
+	found := false
+	for _, signing := range signings {
+		if configuration != "" && signing.Configuration != configuration {
+			continue
+		}
+		found = true
+
+		fmt.Println()
+		log.Printf("%s (%s, %s) - %s", colorstring.Green(signing.Target), signing.Type, signing.Platform, signing.Configuration)
+		log.Printf("  bundle ID: %s", valueOrUnset(signing.BundleID))
+		log.Printf("  team: %s", valueOrUnset(signing.TeamID))
+		log.Printf("  signing style: %s", valueOrUnset(signing.SigningStyle))
+		log.Printf("  code sign identity: %s", valueOrUnset(signing.CodeSignIdentity))
+		log.Printf("  provisioning profile specifier: %s", valueOrUnset(signing.ProvisioningProfileSpecifier))
+	}
+
+	if !found {
+		if configuration != "" {
+			return fmt.Errorf("no application or extension target found with build configuration: %s", configuration)
+		}
+		return fmt.Errorf("no application or extension target found in %s", manifestPath)
+	}
+	return nil
+}
+
+func valueOrUnset(value string) string {
+	if value == "" {
+		return colorstring.Yellow("not set")
+	}
+	return value
+}
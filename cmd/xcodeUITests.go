@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/bitrise-io/codesigndoc/buildlog"
 	"github.com/bitrise-io/codesigndoc/codesign"
 	"github.com/bitrise-io/codesigndoc/codesigndocuitests"
 	codesigndocutility "github.com/bitrise-io/codesigndoc/utility"
@@ -13,7 +14,6 @@ import (
 	"github.com/bitrise-io/go-utils/colorstring"
 	"github.com/bitrise-io/go-utils/fileutil"
 	"github.com/bitrise-io/go-utils/log"
-	"github.com/bitrise-io/go-utils/stringutil"
 	"github.com/bitrise-io/go-xcode/utility"
 	"github.com/bitrise-io/goinp/goinp"
 	"github.com/spf13/cobra"
@@ -45,6 +45,11 @@ func scanXcodeUITestsProject(*cobra.Command, []string) error {
 		return err
 	}
 
+	udids, err := parseDeviceUDIDs()
+	if err != nil {
+		return err
+	}
+
 	// Output tools versions
 	xcodebuildVersion, err := utility.GetXcodeVersion()
 	if err != nil {
@@ -117,61 +122,35 @@ func scanXcodeUITestsProject(*cobra.Command, []string) error {
 		xcodeUITestsCmd.SDK = paramXcodebuildSDK
 	}
 
+	platform, err := detectPlatform(xcodeUITestsCmd.ProjectFilePath, xcodeUITestsCmd.Scheme)
+	if err != nil {
+		log.Debugf("Failed to detect the platform of the scheme (%s), falling back to %s: %s", xcodeUITestsCmd.Scheme, codesigndocutility.IOS, err)
+		platform = codesigndocutility.IOS
+	}
+
 	if paramXcodeDestination != "" {
 		xcodeUITestsCmd.Destination = paramXcodeDestination
-	} else {
-		project, scheme, configuration, err := codesigndocutility.OpenArchivableProject(xcodeUITestsCmd.ProjectFilePath, xcodeUITestsCmd.Scheme, "")
-		if err != nil {
-			return err
-		}
-
-		platform, err := codesigndocutility.BuildableTargetPlatform(project, scheme, configuration, codesigndocutility.XcodeBuild{})
-		if err == nil {
-			destination := "generic/platform=" + string(platform)
+	} else if err == nil {
+		destination := "generic/platform=" + string(platform)
 
-			xcodeUITestsCmd.Destination = destination
+		xcodeUITestsCmd.Destination = destination
 
-			fmt.Print("Setting xcodebuild -destination flag to: ", destination)
-		}
+		fmt.Print("Setting xcodebuild -destination flag to: ", destination)
 	}
 
-	fmt.Println()
-	fmt.Println()
-	log.Printf("🔦  Running an Xcode build-for-testing, to get all the required code signing settings...")
-	xcodebuildOutputFilePath := filepath.Join(absExportOutputDirPath, "xcodebuild-output.log")
-
-	buildForTestingPath, xcodebuildOutput, err := xcodeUITestsCmd.RunBuildForTesting()
-	if writeFiles == codesign.WriteFilesAlways || writeFiles == codesign.WriteFilesFallback && err != nil { // save the xcodebuild output into a debug log file
-		if err := os.MkdirAll(absExportOutputDirPath, 0700); err != nil {
-			return fmt.Errorf("failed to create output directory, error: %s", err)
-		}
-
-		log.Infof("💡  "+colorstring.Yellow("Saving xcodebuild output into file")+": %s", xcodebuildOutputFilePath)
-		if err := fileutil.WriteStringToFile(xcodebuildOutputFilePath, xcodebuildOutput); err != nil {
-			log.Errorf("Failed to save xcodebuild output into file (%s), error: %s", xcodebuildOutputFilePath, err)
-		}
-	}
+	buildForTestingPath, err := runBuildForTesting(xcodeUITestsCmd, "", absExportOutputDirPath, "xcodebuild-output.log")
 	if err != nil {
-		log.Warnf("Last lines of the build log:")
-		fmt.Println(stringutil.LastNLines(xcodebuildOutput, 15))
-
-		log.Infof(colorstring.Yellow("Please check the build log to see what caused the error."))
-		fmt.Println()
-
-		log.Errorf("Xcode Build For Testing failed.")
-		log.Infof(colorstring.Yellow("Open the project: ")+"%s", xcodeUITestsCmd.ProjectFilePath)
-		log.Infof(colorstring.Yellow("and make sure that you can run Build For Testing, with the scheme: ")+"%s", xcodeUITestsCmd.Scheme)
-		fmt.Println()
-
-		return BuildForTestingError{toolXcode, err.Error()}
+		return err
 	}
 
 	// If certificatesOnly is set, CollectCodesignFiles returns an empty slice for profiles
-	certificatesToExport, profilesToExport, err := codesigndocuitests.CollectCodesignFiles(buildForTestingPath, certificatesOnly)
+	certificatesToExport, profilesToExport, err := codesigndocuitests.CollectCodesignFiles(buildForTestingPath, platform, certificatesOnly, selectPolicy, teamID)
 	if err != nil {
 		return err
 	}
 
+	checkProfileDevices(profilesToExport, udids)
+
 	certificates, profiles, err := codesign.ExportCodesigningFiles(certificatesToExport, profilesToExport, isAskForPassword)
 	if err != nil {
 		return err
@@ -186,6 +165,11 @@ func scanXcodeUITestsProject(*cobra.Command, []string) error {
 		codesign.UploadConfig{
 			PersonalAccessToken: personalAccessToken,
 			AppSlug:             appSlug,
+			AppTitle:            appTitle,
+			AppRepoURL:          appRepoURL,
+			AppFilter:           appFilter,
+			IsProtected:         isProtected,
+			IsExposed:           isExposed,
 		})
 	if err != nil {
 		return err
@@ -194,3 +178,50 @@ func scanXcodeUITestsProject(*cobra.Command, []string) error {
 	printFinished(exportResult, absExportOutputDirPath)
 	return nil
 }
+
+// detectPlatform returns the platform of the given scheme's archivable target.
+func detectPlatform(projectPath, schemeName string) (codesigndocutility.Platform, error) {
+	project, scheme, configuration, err := codesigndocutility.OpenArchivableProject(projectPath, schemeName, "")
+	if err != nil {
+		return "", err
+	}
+
+	return codesigndocutility.BuildableTargetPlatform(project, scheme, configuration, codesigndocutility.XcodeBuild{})
+}
+
+// runBuildForTesting runs the build-for-testing action and returns the path of the built products.
+// The build log is saved into the export directory with the given file name, depending on the --write-files flag.
+// The provisioningUpdatesFlag is suggested by the fixes of the code signing failures, see buildlog.Diagnose.
+func runBuildForTesting(xcodeUITestsCmd xcodeuitest.CommandModel, provisioningUpdatesFlag, absExportOutputDirPath, logFileName string) (string, error) {
+	fmt.Println()
+	fmt.Println()
+	log.Printf("🔦  Running an Xcode build-for-testing, to get all the required code signing settings...")
+	xcodebuildOutputFilePath := filepath.Join(absExportOutputDirPath, logFileName)
+
+	buildForTestingPath, xcodebuildOutput, err := xcodeUITestsCmd.RunBuildForTesting()
+	if writeFiles == codesign.WriteFilesAlways || writeFiles == codesign.WriteFilesFallback && err != nil { // save the xcodebuild output into a debug log file
+		if err := os.MkdirAll(absExportOutputDirPath, 0700); err != nil {
+			return "", fmt.Errorf("failed to create output directory, error: %s", err)
+		}
+
+		log.Infof("💡  "+colorstring.Yellow("Saving xcodebuild output into file")+": %s", xcodebuildOutputFilePath)
+		if err := fileutil.WriteStringToFile(xcodebuildOutputFilePath, xcodebuildOutput); err != nil {
+			log.Errorf("Failed to save xcodebuild output into file (%s), error: %s", xcodebuildOutputFilePath, err)
+		}
+	}
+	if err != nil {
+		buildlog.PrintFailure(xcodebuildOutput, provisioningUpdatesFlag)
+
+		log.Infof(colorstring.Yellow("Please check the build log to see what caused the error."))
+		fmt.Println()
+
+		log.Errorf("Xcode Build For Testing failed.")
+		log.Infof(colorstring.Yellow("Open the project: ")+"%s", xcodeUITestsCmd.ProjectFilePath)
+		log.Infof(colorstring.Yellow("and make sure that you can run Build For Testing, with the scheme: ")+"%s", xcodeUITestsCmd.Scheme)
+		fmt.Println()
+
+		return "", BuildForTestingError{toolXcode, err.Error()}
+	}
+
+	return buildForTestingPath, nil
+}
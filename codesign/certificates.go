@@ -32,6 +32,16 @@ var (
 		"Apple Development",                   // type: "Apple Development"
 		"Apple Distribution",                  // type: "Apple Distribution"
 	}
+
+	distributionCertificateNames = []string{
+		"iPhone Distribution",                 // type: "iOS Distribution"
+		"Apple Distribution",                  // type: "Apple Distribution"
+		"3rd Party Mac Developer Application", // type: "Mac App Distribution"
+		"Developer ID Application",            // type: "Developer ID Application"
+		"3rd Party Mac Developer Installer",   // type: "Mac Installer Distribution"
+		"Mac Installer Distribution",          // type: "Mac Installer Distribution"
+		"Developer ID Installer",              // type: "Developer ID Installer"
+	}
 )
 
 // InstalledCertificates returns the certificate installed in the keychain,
@@ -46,19 +56,10 @@ func InstalledCertificates(certType certificateType) ([]certificateutil.Certific
 		certs, err = certificateutil.InstalledCodesigningCertificateInfos()
 		if err == nil {
 			certs = certificateutil.FilterCertificateInfoModelsByFilterFunc(certs, func(cert certificateutil.CertificateInfoModel) bool {
-				var certNames []string
 				if certType == IOSCertificate {
-					certNames = iOSCertificateNames
-				} else {
-					certNames = macOSCertificateNames
-				}
-
-				for _, name := range certNames {
-					if strings.Contains(strings.ToLower(cert.CommonName), strings.ToLower(name)) {
-						return true
-					}
+					return hasCertificateName(cert, iOSCertificateNames)
 				}
-				return false
+				return hasCertificateName(cert, macOSCertificateNames)
 			})
 		}
 	}
@@ -67,17 +68,10 @@ func InstalledCertificates(certType certificateType) ([]certificateutil.Certific
 }
 
 // IsDistributionCertificate returns true if the given certificate
-// is an iOS Distribution, Mac App Distribution or Developer ID Application certificate.
+// is an iOS Distribution, Apple Distribution, Mac App Distribution, Developer ID Application
+// or a Mac installer (Mac Installer Distribution, Developer ID Installer) certificate.
 func IsDistributionCertificate(cert certificateutil.CertificateInfoModel) bool {
-	if strings.Contains(strings.ToLower(cert.CommonName), strings.ToLower("iPhone Distribution")) {
-		return true
-	}
-
-	if strings.Contains(strings.ToLower(cert.CommonName), strings.ToLower("3rd Party Mac Developer Application")) {
-		return true
-	}
-
-	return false
+	return hasCertificateName(cert, distributionCertificateNames)
 }
 
 // IsInstallerCertificate returns true if the given certificate
@@ -86,6 +80,18 @@ func IsInstallerCertificate(cert certificateutil.CertificateInfoModel) bool {
 	return strings.Contains(strings.ToLower(cert.CommonName), strings.ToLower("installer"))
 }
 
+// IsDeveloperIDInstallerCertificate returns true if the given certificate
+// is a Developer ID Installer certificate, used to sign notarized installer packages.
+func IsDeveloperIDInstallerCertificate(cert certificateutil.CertificateInfoModel) bool {
+	return strings.Contains(strings.ToLower(cert.CommonName), strings.ToLower("Developer ID Installer"))
+}
+
+// IsMacAppStoreInstallerCertificate returns true if the given certificate
+// is a Mac Installer Distribution certificate, used to sign installer packages for the Mac App Store.
+func IsMacAppStoreInstallerCertificate(cert certificateutil.CertificateInfoModel) bool {
+	return IsInstallerCertificate(cert) && !IsDeveloperIDInstallerCertificate(cert)
+}
+
 // MapCertificatesByTeam returns a certificate list mapped by the certificate's team (in teamID - teamName format).
 func MapCertificatesByTeam(certificates []certificateutil.CertificateInfoModel) map[string][]certificateutil.CertificateInfoModel {
 	certificatesByTeam := map[string][]certificateutil.CertificateInfoModel{}
@@ -101,6 +107,18 @@ func MapCertificatesByTeam(certificates []certificateutil.CertificateInfoModel)
 	return certificatesByTeam
 }
 
+// FilterCertificatesByTeam returns the certificates of the given team,
+// all certificates are returned if the teamID is empty.
+func FilterCertificatesByTeam(certificates []certificateutil.CertificateInfoModel, teamID string) []certificateutil.CertificateInfoModel {
+	if teamID == "" {
+		return certificates
+	}
+
+	return certificateutil.FilterCertificateInfoModelsByFilterFunc(certificates, func(certificate certificateutil.CertificateInfoModel) bool {
+		return certificate.TeamID == teamID
+	})
+}
+
 // FindCertificate returns the first certificate, which common_name or SHA1 fingerprint matches to the given string.
 func FindCertificate(nameOrSHA1Fingerprint string, certificates []certificateutil.CertificateInfoModel) (certificateutil.CertificateInfoModel, error) {
 	for _, certificate := range certificates {
@@ -113,3 +131,29 @@ func FindCertificate(nameOrSHA1Fingerprint string, certificates []certificateuti
 	}
 	return certificateutil.CertificateInfoModel{}, errors.Errorf("installed certificate not found with common name or sha1 hash: %s", nameOrSHA1Fingerprint)
 }
+
+// MergeCertificates returns the certificates of the given lists, without duplicates (by serial).
+func MergeCertificates(certificateLists ...[]certificateutil.CertificateInfoModel) []certificateutil.CertificateInfoModel {
+	var merged []certificateutil.CertificateInfoModel
+	serials := map[string]bool{}
+	for _, certificates := range certificateLists {
+		for _, certificate := range certificates {
+			if serials[certificate.Serial] {
+				continue
+			}
+			serials[certificate.Serial] = true
+			merged = append(merged, certificate)
+		}
+	}
+	return merged
+}
+
+// hasCertificateName returns true if the given certificate's common name contains any of the given names.
+func hasCertificateName(certificate certificateutil.CertificateInfoModel, names []string) bool {
+	for _, name := range names {
+		if strings.Contains(strings.ToLower(certificate.CommonName), strings.ToLower(name)) {
+			return true
+		}
+	}
+	return false
+}
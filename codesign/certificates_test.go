@@ -0,0 +1,61 @@
+package codesign
+
+import (
+	"testing"
+
+	"github.com/bitrise-io/go-xcode/certificateutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsDistributionCertificate(t *testing.T) {
+	tests := []struct {
+		commonName string
+		want       bool
+	}{
+		{commonName: "iPhone Developer: Test User (ABCD1234)", want: false},
+		{commonName: "Apple Development: Test User (ABCD1234)", want: false},
+		{commonName: "Mac Developer: Test User (ABCD1234)", want: false},
+		{commonName: "iPhone Distribution: Test Team (ABCD1234)", want: true},
+		{commonName: "Apple Distribution: Test Team (ABCD1234)", want: true},
+		{commonName: "3rd Party Mac Developer Application: Test Team (ABCD1234)", want: true},
+		{commonName: "Developer ID Application: Test Team (ABCD1234)", want: true},
+		{commonName: "3rd Party Mac Developer Installer: Test Team (ABCD1234)", want: true},
+		{commonName: "Mac Installer Distribution: Test Team (ABCD1234)", want: true},
+		{commonName: "Developer ID Installer: Test Team (ABCD1234)", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.commonName, func(t *testing.T) {
+			require.Equal(t, tt.want, IsDistributionCertificate(certificateutil.CertificateInfoModel{CommonName: tt.commonName}))
+		})
+	}
+}
+
+func TestInstallerCertificateKinds(t *testing.T) {
+	developerID := certificateutil.CertificateInfoModel{CommonName: "Developer ID Installer: Test Team (ABCD1234)"}
+	appStore := certificateutil.CertificateInfoModel{CommonName: "3rd Party Mac Developer Installer: Test Team (ABCD1234)"}
+
+	require.True(t, IsDeveloperIDInstallerCertificate(developerID))
+	require.False(t, IsMacAppStoreInstallerCertificate(developerID))
+
+	require.False(t, IsDeveloperIDInstallerCertificate(appStore))
+	require.True(t, IsMacAppStoreInstallerCertificate(appStore))
+}
+
+func TestFilterCertificatesByTeam(t *testing.T) {
+	certificates := []certificateutil.CertificateInfoModel{
+		{Serial: "1", TeamID: "TEAMA"},
+		{Serial: "2", TeamID: "TEAMB"},
+	}
+
+	require.Equal(t, certificates, FilterCertificatesByTeam(certificates, ""))
+	require.Equal(t, []certificateutil.CertificateInfoModel{certificates[1]}, FilterCertificatesByTeam(certificates, "TEAMB"))
+	require.Empty(t, FilterCertificatesByTeam(certificates, "TEAMC"))
+}
+
+func TestMergeCertificates(t *testing.T) {
+	app := []certificateutil.CertificateInfoModel{{Serial: "1"}, {Serial: "2"}}
+	uiTests := []certificateutil.CertificateInfoModel{{Serial: "2"}, {Serial: "3"}}
+
+	require.Equal(t, []certificateutil.CertificateInfoModel{{Serial: "1"}, {Serial: "2"}, {Serial: "3"}}, MergeCertificates(app, uiTests))
+	require.Empty(t, MergeCertificates(nil, nil))
+}
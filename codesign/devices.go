@@ -0,0 +1,79 @@
+package codesign
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/bitrise-io/go-xcode/exportoptions"
+	"github.com/bitrise-io/go-xcode/profileutil"
+)
+
+// ProfileDevicesReport lists the devices missing from a development or ad-hoc provisioning profile.
+type ProfileDevicesReport struct {
+	Profile        profileutil.ProvisioningProfileInfoModel
+	MissingDevices []string
+}
+
+// ParseDeviceUDIDs returns the device UDIDs of the given device list.
+// Every line holds a UDID, optionally followed by tab or comma separated columns,
+// which makes the device list files exported from the Apple Developer Portal readable too.
+// Empty lines, # comments and the "Device ID" header line are skipped.
+func ParseDeviceUDIDs(content string) []string {
+	var udids []string
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		udid := line
+		if idx := strings.IndexAny(line, "\t,"); idx != -1 {
+			udid = strings.TrimSpace(line[:idx])
+		}
+		if udid == "" || strings.EqualFold(udid, "Device ID") {
+			continue
+		}
+
+		udids = append(udids, udid)
+	}
+	return udids
+}
+
+// CheckProfileDevices returns a report for each development and ad-hoc profile missing any of the given devices.
+// Profiles provisioning all devices and profiles of other export methods are skipped.
+func CheckProfileDevices(profiles []profileutil.ProvisioningProfileInfoModel, udids []string) []ProfileDevicesReport {
+	var reports []ProfileDevicesReport
+	for _, profile := range profiles {
+		if profile.ExportType != exportoptions.MethodDevelopment && profile.ExportType != exportoptions.MethodAdHoc {
+			continue
+		}
+		if profile.ProvisionsAllDevices {
+			continue
+		}
+
+		if missing := missingDevices(profile.ProvisionedDevices, udids); len(missing) > 0 {
+			reports = append(reports, ProfileDevicesReport{Profile: profile, MissingDevices: missing})
+		}
+	}
+
+	sort.SliceStable(reports, func(i, j int) bool {
+		return reports[i].Profile.Name < reports[j].Profile.Name
+	})
+	return reports
+}
+
+// missingDevices returns the UDIDs not included in the provisioned devices, compared case insensitively.
+func missingDevices(provisionedDevices, udids []string) []string {
+	provisioned := map[string]bool{}
+	for _, device := range provisionedDevices {
+		provisioned[strings.ToLower(device)] = true
+	}
+
+	var missing []string
+	for _, udid := range udids {
+		if !provisioned[strings.ToLower(udid)] {
+			missing = append(missing, udid)
+		}
+	}
+	return missing
+}
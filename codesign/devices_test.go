@@ -0,0 +1,43 @@
+package codesign
+
+import (
+	"testing"
+
+	"github.com/bitrise-io/go-xcode/exportoptions"
+	"github.com/bitrise-io/go-xcode/profileutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDeviceUDIDs(t *testing.T) {
+	content := `Device ID	Device Name	Device Platform
+00008030-001A2B3C4D5E6F70	QA iPhone 11	ios
+# retired
+a1b2c3d4e5f60718293a4b5c6d7e8f9012345678, QA iPad
+
+  00008101-000123456789ABCD
+`
+
+	require.Equal(t, []string{
+		"00008030-001A2B3C4D5E6F70",
+		"a1b2c3d4e5f60718293a4b5c6d7e8f9012345678",
+		"00008101-000123456789ABCD",
+	}, ParseDeviceUDIDs(content))
+}
+
+func TestCheckProfileDevices(t *testing.T) {
+	udids := []string{"00008030-001A2B3C4D5E6F70", "00008101-000123456789ABCD"}
+
+	profiles := []profileutil.ProvisioningProfileInfoModel{
+		{Name: "Development", UUID: "uuid-1", ExportType: exportoptions.MethodDevelopment, ProvisionedDevices: []string{"00008030-001a2b3c4d5e6f70", "00008101-000123456789ABCD"}},
+		{Name: "Ad Hoc", UUID: "uuid-2", ExportType: exportoptions.MethodAdHoc, ProvisionedDevices: []string{"00008030-001A2B3C4D5E6F70"}},
+		{Name: "App Store", UUID: "uuid-3", ExportType: exportoptions.MethodAppStore},
+		{Name: "Enterprise Development", UUID: "uuid-4", ExportType: exportoptions.MethodDevelopment, ProvisionsAllDevices: true},
+	}
+
+	reports := CheckProfileDevices(profiles, udids)
+	require.Equal(t, 1, len(reports))
+	require.Equal(t, "uuid-2", reports[0].Profile.UUID)
+	require.Equal(t, []string{"00008101-000123456789ABCD"}, reports[0].MissingDevices)
+
+	require.Nil(t, CheckProfileDevices(profiles, nil))
+}
@@ -0,0 +1,103 @@
+package codesign
+
+import (
+	"sort"
+	"time"
+
+	"github.com/bitrise-io/go-xcode/certificateutil"
+	"github.com/bitrise-io/go-xcode/profileutil"
+)
+
+// ExpiryStatus ...
+type ExpiryStatus int
+
+// ExpiryStatuses, ordered by severity.
+const (
+	ExpiryStatusValid ExpiryStatus = iota
+	ExpiryStatusExpiringSoon
+	ExpiryStatusExpired
+)
+
+// String ...
+func (status ExpiryStatus) String() string {
+	switch status {
+	case ExpiryStatusExpiringSoon:
+		return "expiring soon"
+	case ExpiryStatusExpired:
+		return "expired"
+	default:
+		return "valid"
+	}
+}
+
+// ExpiryItem is a certificate or provisioning profile with its expiry status.
+type ExpiryItem struct {
+	Kind           string
+	Name           string
+	ID             string
+	Source         string
+	ExpirationDate time.Time
+	Status         ExpiryStatus
+}
+
+// expiryStatus returns expired if the expiration date is not after now,
+// expiring soon if it is within the given window.
+func expiryStatus(expirationDate, now time.Time, window time.Duration) ExpiryStatus {
+	if !now.Before(expirationDate) {
+		return ExpiryStatusExpired
+	}
+	if !now.Add(window).Before(expirationDate) {
+		return ExpiryStatusExpiringSoon
+	}
+	return ExpiryStatusValid
+}
+
+// CertificatesExpiry returns the expiry status of the given certificates.
+func CertificatesExpiry(certificates []certificateutil.CertificateInfoModel, source string, now time.Time, window time.Duration) []ExpiryItem {
+	var items []ExpiryItem
+	for _, certificate := range certificates {
+		items = append(items, ExpiryItem{
+			Kind:           "certificate",
+			Name:           certificate.CommonName,
+			ID:             certificate.Serial,
+			Source:         source,
+			ExpirationDate: certificate.EndDate,
+			Status:         expiryStatus(certificate.EndDate, now, window),
+		})
+	}
+	return items
+}
+
+// ProfilesExpiry returns the expiry status of the given provisioning profiles.
+func ProfilesExpiry(profiles []profileutil.ProvisioningProfileInfoModel, source string, now time.Time, window time.Duration) []ExpiryItem {
+	var items []ExpiryItem
+	for _, profile := range profiles {
+		items = append(items, ExpiryItem{
+			Kind:           "provisioning profile",
+			Name:           profile.Name,
+			ID:             profile.UUID,
+			Source:         source,
+			ExpirationDate: profile.ExpirationDate,
+			Status:         expiryStatus(profile.ExpirationDate, now, window),
+		})
+	}
+	return items
+}
+
+// SortExpiryItems sorts the given items by expiration date, the first expiring first.
+func SortExpiryItems(items []ExpiryItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].ExpirationDate.Before(items[j].ExpirationDate)
+	})
+}
+
+// WorstExpiryStatus returns the most severe status of the given items.
+func WorstExpiryStatus(items []ExpiryItem) ExpiryStatus {
+	worst := ExpiryStatusValid
+	for _, item := range items {
+		if item.Status > worst {
+			worst = item.Status
+		}
+	}
+	return worst
+}
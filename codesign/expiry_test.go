@@ -0,0 +1,44 @@
+package codesign
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bitrise-io/go-xcode/certificateutil"
+	"github.com/bitrise-io/go-xcode/profileutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpiryStatus(t *testing.T) {
+	now := createTime(t, "2021.01.01")
+	window := 30 * 24 * time.Hour
+
+	require.Equal(t, ExpiryStatusExpired, expiryStatus(createTime(t, "2020.12.01"), now, window))
+	require.Equal(t, ExpiryStatusExpired, expiryStatus(now, now, window))
+	require.Equal(t, ExpiryStatusExpiringSoon, expiryStatus(createTime(t, "2021.01.20"), now, window))
+	require.Equal(t, ExpiryStatusExpiringSoon, expiryStatus(createTime(t, "2021.01.31"), now, window))
+	require.Equal(t, ExpiryStatusValid, expiryStatus(createTime(t, "2021.02.01"), now, window))
+}
+
+func TestWorstExpiryStatus(t *testing.T) {
+	now := createTime(t, "2021.01.01")
+	window := 30 * 24 * time.Hour
+
+	certificates := []certificateutil.CertificateInfoModel{
+		{CommonName: "Apple Distribution", Serial: "1", EndDate: createTime(t, "2022.01.01")},
+	}
+	profiles := []profileutil.ProvisioningProfileInfoModel{
+		{Name: "App Store", UUID: "uuid-1", ExpirationDate: createTime(t, "2021.01.10")},
+	}
+
+	items := append(CertificatesExpiry(certificates, "local", now, window), ProfilesExpiry(profiles, "local", now, window)...)
+	require.Equal(t, ExpiryStatusExpiringSoon, WorstExpiryStatus(items))
+
+	SortExpiryItems(items)
+	require.Equal(t, "uuid-1", items[0].ID)
+
+	profiles = append(profiles, profileutil.ProvisioningProfileInfoModel{Name: "Ad Hoc", UUID: "uuid-2", ExpirationDate: createTime(t, "2020.01.01")})
+	require.Equal(t, ExpiryStatusExpired, WorstExpiryStatus(ProfilesExpiry(profiles, "local", now, window)))
+
+	require.Equal(t, ExpiryStatusValid, WorstExpiryStatus(nil))
+}
@@ -17,12 +17,22 @@ import (
 	"github.com/bitrise-io/go-xcode/certificateutil"
 	"github.com/bitrise-io/go-xcode/profileutil"
 	"github.com/bitrise-io/goinp/goinp"
+	"golang.org/x/term"
 )
 
 // UploadConfig contains configuration to automatically upload artifacts to bitrise.io.
 type UploadConfig struct {
 	PersonalAccessToken string
 	AppSlug             string
+	// AppTitle and AppRepoURL select the app instead of the app slug, they fail if more than one app matches.
+	AppTitle   string
+	AppRepoURL string
+	// AppFilter filters the apps offered by the interactive app selection.
+	AppFilter bitriseio.AppFilter
+	// IsProtected uploads the files as protected.
+	IsProtected bool
+	// IsExposed exposes the uploaded files to pull request builds.
+	IsExposed bool
 }
 
 // WriteFilesConfig controls writing artifacts as files.
@@ -69,18 +79,22 @@ func ExportCodesigningFiles(certificatesRequired []certificateutil.CertificateIn
 
 // UploadAndWriteCodesignFiles exports then uploads codesign files to bitrise.io and saves them to output folder.
 func UploadAndWriteCodesignFiles(certificates models.Certificates, provisioningProfiles []models.ProvisioningProfile, writeFilesConfig WriteFilesConfig, uploadConfig UploadConfig) (ExportReport, error) {
-	var client *bitrise.Client
+	appSelector := bitriseio.AppSelector{
+		Slug:    uploadConfig.AppSlug,
+		Title:   uploadConfig.AppTitle,
+		RepoURL: uploadConfig.AppRepoURL,
+		Filter:  uploadConfig.AppFilter,
+	}
+
+	var client bitrise.API
 	// both or none CLI flags are required
-	if uploadConfig.PersonalAccessToken != "" && uploadConfig.AppSlug != "" {
+	if uploadConfig.PersonalAccessToken != "" && appSelector.IsSet() {
 		// Upload automatically if token is provided as CLI parameter, do not export to filesystem.
 		// Used to upload artifacts as part of another CLI tool
 		var err error
-		client, err = bitrise.NewClient(uploadConfig.PersonalAccessToken)
-		if err != nil {
+		if client, err = bitriseio.NewConfigClient(uploadConfig.PersonalAccessToken, appSelector); err != nil {
 			return ExportReport{}, err
 		}
-
-		client.SetSelectedAppSlug(uploadConfig.AppSlug)
 	}
 
 	if client == nil {
@@ -96,7 +110,7 @@ func UploadAndWriteCodesignFiles(certificates models.Certificates, provisioningP
 		}
 
 		if shouldUpload {
-			if client, err = bitriseio.GetInteractiveConfigClient(); err != nil {
+			if client, err = bitriseio.GetInteractiveConfigClient(appSelector); err != nil {
 				return ExportReport{}, err
 			}
 		}
@@ -119,7 +133,15 @@ func UploadAndWriteCodesignFiles(certificates models.Certificates, provisioningP
 		}, nil
 	}
 
-	certificatesUploaded, profilesUploaded, err := bitriseio.UploadCodesigningFiles(client, certificates, provisioningProfiles)
+	options, err := uploadOptions(certificates, uploadConfig)
+	if err != nil {
+		return ExportReport{CodesignFilesWritten: filesWritten}, err
+	}
+
+	certificatesUploaded, profilesUploaded, err := bitriseio.UploadCodesigningFiles(client, certificates, provisioningProfiles, options)
+	if errors.Is(err, bitrise.ErrUnauthorized) || errors.Is(err, bitrise.ErrForbidden) || errors.Is(err, bitrise.ErrNotFound) {
+		err = fmt.Errorf("failed to upload to Bitrise, check the access token and the app slug: %w", err)
+	}
 	return ExportReport{
 		CertificatesUploaded:         certificatesUploaded,
 		ProvisioningProfilesUploaded: profilesUploaded,
@@ -127,6 +149,51 @@ func UploadAndWriteCodesignFiles(certificates models.Certificates, provisioningP
 	}, err
 }
 
+// uploadOptions returns the attributes of the uploaded files and the passphrase of the exported .p12 file.
+func uploadOptions(certificates models.Certificates, uploadConfig UploadConfig) (bitriseio.UploadOptions, error) {
+	p12Password, err := identitiesPassword(certificates)
+	if err != nil {
+		return bitriseio.UploadOptions{}, err
+	}
+
+	return bitriseio.UploadOptions{
+		P12Password:         p12Password,
+		IdentitiesProtected: uploadConfig.IsProtected,
+		IdentitiesExposed:   uploadConfig.IsExposed,
+		ProfilesProtected:   uploadConfig.IsProtected,
+		ProfilesExposed:     uploadConfig.IsExposed,
+	}, nil
+}
+
+// identitiesPassword returns the passphrase of the exported .p12 file.
+// If the identities were not exported with an empty passphrase, it is asked, as it can not be read from the Keychain export.
+func identitiesPassword(certificates models.Certificates) (string, error) {
+	if len(certificates.Content) == 0 {
+		return "", nil
+	}
+	if _, err := certificateutil.CertificatesFromPKCS12Content(certificates.Content, ""); err == nil {
+		return "", nil
+	}
+
+	const maxAttempts = 3
+	fmt.Println()
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		fmt.Print("Enter the passphrase of the exported .p12 file, it is uploaded with the file to Bitrise: ")
+		passwordBytes, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Println()
+		if err != nil {
+			return "", fmt.Errorf("failed to read the passphrase, error: %s", err)
+		}
+		password := string(passwordBytes)
+
+		if _, err := certificateutil.CertificatesFromPKCS12Content(certificates.Content, password); err == nil {
+			return password, nil
+		}
+		log.Warnf("Failed to open the .p12 file with the given passphrase.")
+	}
+	return "", errors.New("failed to open the exported .p12 file with the given passphrase")
+}
+
 func writeFiles(identities models.Certificates, provisioningProfiles []models.ProvisioningProfile, writeFilesConfig WriteFilesConfig) error {
 	if err := os.MkdirAll(writeFilesConfig.AbsOutputDirPath, 0700); err != nil {
 		return fmt.Errorf("failed to create output directory for codesigning files, error: %s", err)
@@ -0,0 +1,64 @@
+package codesign
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/bitrise-io/go-xcode/certificateutil"
+	"github.com/bitrise-io/go-xcode/profileutil"
+)
+
+// ReadExportDir reads the code signing files written into an export directory (i.e. ./codesigndoc_exports):
+// the certificates of the .p12 files (decrypted with the given password) and the provisioning profiles.
+func ReadExportDir(dir, p12Password string) ([]certificateutil.CertificateInfoModel, []profileutil.ProvisioningProfileInfoModel, error) {
+	if exist, err := pathutil.IsDirExists(dir); err != nil {
+		return nil, nil, fmt.Errorf("failed to check if export directory exists at: %s, error: %s", dir, err)
+	} else if !exist {
+		return nil, nil, fmt.Errorf("export directory not exists at: %s", dir)
+	}
+
+	identityPaths, err := globExportDir(dir, "*.p12")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var certificates []certificateutil.CertificateInfoModel
+	for _, pth := range identityPaths {
+		identityCertificates, err := certificateutil.CertificatesFromPKCS12File(pth, p12Password)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read certificates from %s, error: %s", pth, err)
+		}
+		certificates = append(certificates, identityCertificates...)
+	}
+
+	profilePaths, err := globExportDir(dir, "*.mobileprovision", "*.provisionprofile")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var profiles []profileutil.ProvisioningProfileInfoModel
+	for _, pth := range profilePaths {
+		profile, err := profileutil.NewProvisioningProfileInfoFromFile(pth)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read provisioning profile from %s, error: %s", pth, err)
+		}
+		profiles = append(profiles, profile)
+	}
+
+	return certificates, profiles, nil
+}
+
+func globExportDir(dir string, patterns ...string) ([]string, error) {
+	var paths []string
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(filepath.Join(pathutil.EscapeGlobPath(dir), pattern))
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, matches...)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
@@ -0,0 +1,72 @@
+package codesign
+
+import (
+	"fmt"
+
+	"github.com/bitrise-io/go-xcode/exportoptions"
+)
+
+var (
+	iOSExportMethods = []string{
+		"development",
+		"debugging", // Xcode 15.3+ name of development
+		"app-store",
+		"app-store-connect", // Xcode 15.3+ name of app-store
+		"validation",        // Xcode 15.3+, app-store signed export for validation only
+		"ad-hoc",
+		"release-testing", // Xcode 15.3+ name of ad-hoc
+		"enterprise",
+	}
+
+	macOSExportMethods = []string{
+		"development",
+		"debugging", // Xcode 15.3+ name of development
+		"app-store",
+		"app-store-connect", // Xcode 15.3+ name of app-store
+		"validation",        // Xcode 15.3+, app-store signed export for validation only
+		"developer-id",
+		// "mac-application" is not supported: it copies the app as it was signed in the archive,
+		// so it requires no export specific code signing files.
+	}
+
+	// profileExportTypeByExportMethod maps the export methods to the export type of the provisioning profiles,
+	// installed profiles are only classified by the legacy export method names.
+	profileExportTypeByExportMethod = map[string]exportoptions.Method{
+		"development":       exportoptions.MethodDevelopment,
+		"debugging":         exportoptions.MethodDevelopment,
+		"app-store":         exportoptions.MethodAppStore,
+		"app-store-connect": exportoptions.MethodAppStore,
+		"validation":        exportoptions.MethodAppStore,
+		"ad-hoc":            exportoptions.MethodAdHoc,
+		"release-testing":   exportoptions.MethodAdHoc,
+		"enterprise":        exportoptions.MethodEnterprise,
+		"developer-id":      exportoptions.MethodDeveloperID,
+	}
+)
+
+// ExportMethods returns the export methods available for an iOS or a macOS archive.
+func ExportMethods(isMacArchive bool) []string {
+	if isMacArchive {
+		return append([]string{}, macOSExportMethods...)
+	}
+	return append([]string{}, iOSExportMethods...)
+}
+
+// ProfileExportType returns the provisioning profile export type required by the given export method.
+func ProfileExportType(exportMethod string) (exportoptions.Method, error) {
+	exportType, ok := profileExportTypeByExportMethod[exportMethod]
+	if !ok {
+		return "", fmt.Errorf("unknown export method: %s", exportMethod)
+	}
+	return exportType, nil
+}
+
+// IsDevelopmentExportMethod returns true if the given export method requires a development certificate.
+func IsDevelopmentExportMethod(exportMethod string) bool {
+	return profileExportTypeByExportMethod[exportMethod] == exportoptions.MethodDevelopment
+}
+
+// IsAppStoreExportMethod returns true if the given export method signs for App Store distribution.
+func IsAppStoreExportMethod(exportMethod string) bool {
+	return profileExportTypeByExportMethod[exportMethod] == exportoptions.MethodAppStore
+}
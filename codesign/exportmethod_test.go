@@ -0,0 +1,43 @@
+package codesign
+
+import (
+	"testing"
+
+	"github.com/bitrise-io/go-xcode/exportoptions"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfileExportType(t *testing.T) {
+	for _, isMacArchive := range []bool{false, true} {
+		for _, method := range ExportMethods(isMacArchive) {
+			_, err := ProfileExportType(method)
+			require.NoError(t, err, method)
+		}
+	}
+
+	tests := map[string]exportoptions.Method{
+		"debugging":         exportoptions.MethodDevelopment,
+		"release-testing":   exportoptions.MethodAdHoc,
+		"app-store-connect": exportoptions.MethodAppStore,
+		"validation":        exportoptions.MethodAppStore,
+		"enterprise":        exportoptions.MethodEnterprise,
+		"developer-id":      exportoptions.MethodDeveloperID,
+	}
+	for method, want := range tests {
+		got, err := ProfileExportType(method)
+		require.NoError(t, err)
+		require.Equal(t, want, got, method)
+	}
+
+	_, err := ProfileExportType("unknown")
+	require.Error(t, err)
+}
+
+func TestExportMethods_MacApplication(t *testing.T) {
+	require.NotContains(t, ExportMethods(true), "mac-application")
+	require.NotContains(t, ExportMethods(false), "mac-application")
+
+	_, err := ProfileExportType("mac-application")
+	require.Error(t, err)
+	require.False(t, IsDevelopmentExportMethod("mac-application"))
+}
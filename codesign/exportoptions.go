@@ -0,0 +1,89 @@
+package codesign
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-io/go-xcode/export"
+	"github.com/bitrise-io/go-xcode/exportoptions"
+)
+
+const signingStyleManual = "manual"
+
+// ExportOptions is the content of an export options plist, used by xcodebuild -exportArchive, for an export method.
+type ExportOptions struct {
+	ExportMethod string
+	Options      map[string]interface{}
+}
+
+// NewExportOptions returns the export options to export an archive with the given export method
+// manually signed with the code signing files of the given codesign group.
+func NewExportOptions(exportMethod string, group export.CodeSignGroup) ExportOptions {
+	bundleIDProfileNameMap := map[string]string{}
+	for bundleID, profile := range group.BundleIDProfileMap() {
+		bundleIDProfileNameMap[bundleID] = profile.Name
+	}
+
+	var options map[string]interface{}
+	if IsAppStoreExportMethod(exportMethod) {
+		appStoreOptions := exportoptions.NewAppStoreOptions()
+		appStoreOptions.TeamID = group.Certificate().TeamID
+		appStoreOptions.SigningStyle = signingStyleManual
+		appStoreOptions.SigningCertificate = group.Certificate().CommonName
+		appStoreOptions.BundleIDProvisioningProfileMapping = bundleIDProfileNameMap
+		if installerCertificate := group.InstallerCertificate(); installerCertificate != nil && installerCertificate.Serial != "" {
+			appStoreOptions.InstallerSigningCertificate = installerCertificate.CommonName
+		}
+		options = appStoreOptions.Hash()
+	} else {
+		nonAppStoreOptions := exportoptions.NewNonAppStoreOptions(exportoptions.Method(exportMethod))
+		nonAppStoreOptions.TeamID = group.Certificate().TeamID
+		nonAppStoreOptions.SigningStyle = signingStyleManual
+		nonAppStoreOptions.SigningCertificate = group.Certificate().CommonName
+		nonAppStoreOptions.BundleIDProvisioningProfileMapping = bundleIDProfileNameMap
+		options = nonAppStoreOptions.Hash()
+
+		// The non App Store options model has no installer certificate, but Developer ID exports of a macOS archive can use one.
+		if installerCertificate := group.InstallerCertificate(); installerCertificate != nil && installerCertificate.Serial != "" {
+			options[exportoptions.InstallerSigningCertificateKey] = installerCertificate.CommonName
+		}
+	}
+
+	// The App Store options model always sets the legacy method, the Xcode 15.3+ method names are kept as requested.
+	options[exportoptions.MethodKey] = exportMethod
+
+	return ExportOptions{
+		ExportMethod: exportMethod,
+		Options:      options,
+	}
+}
+
+// FileName returns the name of the export options plist file of the export method.
+func (options ExportOptions) FileName() string {
+	return fmt.Sprintf("ExportOptions-%s.plist", options.ExportMethod)
+}
+
+// WriteExportOptions writes the given export options as ExportOptions-<method>.plist files to the output directory.
+func WriteExportOptions(exportOptions []ExportOptions, absOutputDirPath string) error {
+	if len(exportOptions) == 0 {
+		return nil
+	}
+
+	if err := os.MkdirAll(absOutputDirPath, 0700); err != nil {
+		return fmt.Errorf("failed to create output directory, error: %s", err)
+	}
+
+	fmt.Println()
+
+	for _, options := range exportOptions {
+		pth := filepath.Join(absOutputDirPath, options.FileName())
+		if err := exportoptions.WritePlistToFile(options.Options, pth); err != nil {
+			return fmt.Errorf("failed to write export options to %s, error: %s", pth, err)
+		}
+		log.Printf("Export options for %s export written to: %s", options.ExportMethod, pth)
+	}
+
+	return nil
+}
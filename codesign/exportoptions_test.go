@@ -0,0 +1,63 @@
+package codesign
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bitrise-io/go-xcode/certificateutil"
+	"github.com/bitrise-io/go-xcode/export"
+	"github.com/bitrise-io/go-xcode/profileutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewExportOptions(t *testing.T) {
+	certificate := certificateutil.CertificateInfoModel{CommonName: "Apple Distribution: Test Team (ABCD1234)", Serial: "1", TeamID: "ABCD1234"}
+	bundleIDProfileMap := map[string]profileutil.ProvisioningProfileInfoModel{
+		"io.bitrise.app":       {Name: "App Store", UUID: "uuid-1"},
+		"io.bitrise.app.today": {Name: "Today App Store", UUID: "uuid-2"},
+	}
+
+	options := NewExportOptions("app-store-connect", export.NewIOSGroup(certificate, bundleIDProfileMap))
+	require.Equal(t, "ExportOptions-app-store-connect.plist", options.FileName())
+	require.Equal(t, map[string]interface{}{
+		"method":             "app-store-connect",
+		"teamID":             "ABCD1234",
+		"signingStyle":       "manual",
+		"signingCertificate": "Apple Distribution: Test Team (ABCD1234)",
+		"provisioningProfiles": map[string]string{
+			"io.bitrise.app":       "App Store",
+			"io.bitrise.app.today": "Today App Store",
+		},
+	}, options.Options)
+
+	installerCertificate := certificateutil.CertificateInfoModel{CommonName: "Developer ID Installer: Test Team (ABCD1234)", Serial: "2", TeamID: "ABCD1234"}
+	options = NewExportOptions("developer-id", export.NewMacGroup(certificate, &installerCertificate, bundleIDProfileMap))
+	require.Equal(t, "developer-id", options.Options["method"])
+	require.Equal(t, "Developer ID Installer: Test Team (ABCD1234)", options.Options["installerSigningCertificate"])
+
+	options = NewExportOptions("development", export.NewMacGroup(certificate, &certificateutil.CertificateInfoModel{}, bundleIDProfileMap))
+	_, ok := options.Options["installerSigningCertificate"]
+	require.False(t, ok)
+}
+
+func TestWriteExportOptions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "exportoptions")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(dir))
+	}()
+
+	certificate := certificateutil.CertificateInfoModel{CommonName: "Apple Development: Test User (ABCD1234)", Serial: "1", TeamID: "ABCD1234"}
+	options := []ExportOptions{
+		NewExportOptions("development", export.NewIOSGroup(certificate, nil)),
+		NewExportOptions("ad-hoc", export.NewIOSGroup(certificate, nil)),
+	}
+	require.NoError(t, WriteExportOptions(options, dir))
+
+	for _, name := range []string{"ExportOptions-development.plist", "ExportOptions-ad-hoc.plist"} {
+		_, err := os.Stat(filepath.Join(dir, name))
+		require.NoError(t, err)
+	}
+}
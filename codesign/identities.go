@@ -0,0 +1,231 @@
+package codesign
+
+import (
+	"bufio"
+	"encoding/pem"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bitrise-io/go-utils/command"
+	"github.com/bitrise-io/go-xcode/certificateutil"
+	"github.com/bitrise-io/go-xcode/profileutil"
+)
+
+// Certificate kinds listed by the identities list command.
+const (
+	IdentityKindIOS       = "iOS"
+	IdentityKindMacOS     = "macOS"
+	IdentityKindInstaller = "installer"
+)
+
+// KeychainIdentities contains every code signing and installer certificate found in the keychain,
+// including the expired ones and the ones without private key.
+type KeychainIdentities struct {
+	Certificates []certificateutil.CertificateInfoModel
+	// PrivateKeySHA1Fingerprints are the (lowercase) SHA1 fingerprints of the certificates with a private key in the keychain.
+	PrivateKeySHA1Fingerprints map[string]bool
+	// ValidSHA1Fingerprints are the (lowercase) SHA1 fingerprints of the identities listed by security find-identity -v,
+	// only these are seen by InstalledCertificates.
+	ValidSHA1Fingerprints map[string]bool
+}
+
+// IdentityInfo describes an installed certificate and the reasons why it is not used for code signing, if any.
+type IdentityInfo struct {
+	CommonName      string    `json:"common_name"`
+	TeamName        string    `json:"team_name"`
+	TeamID          string    `json:"team_id"`
+	Serial          string    `json:"serial"`
+	SHA1Fingerprint string    `json:"sha1_fingerprint"`
+	ExpirationDate  time.Time `json:"expiration_date"`
+	Kinds           []string  `json:"kinds"`
+	HasPrivateKey   bool      `json:"has_private_key"`
+	FilterReasons   []string  `json:"filter_reasons"`
+	Profiles        []string  `json:"profiles"`
+}
+
+var identityLineRegexp = regexp.MustCompile(`^[0-9]+\) (?P<hash>[0-9A-Fa-f]{40}) "(?P<name>.*)"`)
+
+// InstalledKeychainIdentities returns every code signing and installer certificate of the keychain, without filtering.
+// The certificates are read from the keychain, as security find-identity lists the certificates with a private key only.
+func InstalledKeychainIdentities() (KeychainIdentities, error) {
+	identities := KeychainIdentities{
+		PrivateKeySHA1Fingerprints: map[string]bool{},
+		ValidSHA1Fingerprints:      map[string]bool{},
+	}
+
+	for _, policy := range []string{"codesigning", "macappstore"} {
+		cmd := command.New("security", "find-identity", "-p", policy)
+		out, err := cmd.RunAndReturnTrimmedCombinedOutput()
+		if err != nil {
+			return KeychainIdentities{}, fmt.Errorf("%s failed, output: %s, error: %s", cmd.PrintableCommandArgs(), out, err)
+		}
+
+		if err := parseFindIdentityOutput(out, identities.PrivateKeySHA1Fingerprints, identities.ValidSHA1Fingerprints); err != nil {
+			return KeychainIdentities{}, err
+		}
+	}
+
+	cmd := command.New("security", "find-certificate", "-a", "-p")
+	out, err := cmd.RunAndReturnTrimmedCombinedOutput()
+	if err != nil {
+		return KeychainIdentities{}, fmt.Errorf("%s failed, output: %s, error: %s", cmd.PrintableCommandArgs(), out, err)
+	}
+
+	certificates, err := parseCertificatesPEM(out)
+	if err != nil {
+		return KeychainIdentities{}, err
+	}
+	identities.Certificates = filterIdentityCertificates(certificates, identities.PrivateKeySHA1Fingerprints)
+
+	return identities, nil
+}
+
+// parseCertificatesPEM returns the certificates of the security find-certificate -p command's output.
+func parseCertificatesPEM(out string) ([]certificateutil.CertificateInfoModel, error) {
+	var certificates []certificateutil.CertificateInfoModel
+	rest := []byte(out)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		certificate, err := certificateutil.CertificateFromDERContent(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate, error: %s", err)
+		}
+		certificates = append(certificates, certificateutil.NewCertificateInfo(*certificate, nil))
+	}
+	return certificates, nil
+}
+
+// filterIdentityCertificates returns the code signing and installer certificates, and the other certificates with a private key,
+// the certificate authorities and the other certificates of the keychain are dropped. The same certificate is returned once.
+func filterIdentityCertificates(certificates []certificateutil.CertificateInfoModel, privateKeySHA1Fingerprints map[string]bool) []certificateutil.CertificateInfoModel {
+	var filtered []certificateutil.CertificateInfoModel
+	fingerprints := map[string]bool{}
+	for _, certificate := range certificates {
+		fingerprint := strings.ToLower(certificate.SHA1Fingerprint)
+		if fingerprints[fingerprint] {
+			continue
+		}
+		if len(identityKinds(certificate)) == 0 && !privateKeySHA1Fingerprints[fingerprint] {
+			continue
+		}
+		fingerprints[fingerprint] = true
+		filtered = append(filtered, certificate)
+	}
+	return filtered
+}
+
+// parseFindIdentityOutput collects the SHA1 hashes of the security find-identity command's output,
+// the hashes listed under the "Valid identities only" section are collected as valid identities too.
+func parseFindIdentityOutput(out string, sha1Fingerprints, validSHA1Fingerprints map[string]bool) error {
+	isValidSection := false
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "Valid identities only") {
+			isValidSection = true
+			continue
+		}
+
+		matches := identityLineRegexp.FindStringSubmatch(line)
+		if len(matches) != 3 {
+			continue
+		}
+
+		sha1Fingerprints[strings.ToLower(matches[1])] = true
+		if isValidSection {
+			validSHA1Fingerprints[strings.ToLower(matches[1])] = true
+		}
+	}
+	return scanner.Err()
+}
+
+// DescribeIdentities returns the details of the given keychain identities:
+// the kinds they are used as, the reasons they are filtered out by InstalledCertificates
+// and the installed provisioning profiles including them.
+func DescribeIdentities(identities KeychainIdentities, profiles []profileutil.ProvisioningProfileInfoModel, now time.Time) []IdentityInfo {
+	var infos []IdentityInfo
+	nameToCandidates := map[string][]int{}
+	for _, certificate := range identities.Certificates {
+		info := IdentityInfo{
+			CommonName:      certificate.CommonName,
+			TeamName:        certificate.TeamName,
+			TeamID:          certificate.TeamID,
+			Serial:          certificate.Serial,
+			SHA1Fingerprint: certificate.SHA1Fingerprint,
+			ExpirationDate:  certificate.EndDate,
+			Kinds:           identityKinds(certificate),
+			HasPrivateKey:   identities.PrivateKeySHA1Fingerprints[strings.ToLower(certificate.SHA1Fingerprint)],
+			FilterReasons:   []string{},
+			Profiles:        []string{},
+		}
+
+		if len(info.Kinds) == 0 {
+			info.FilterReasons = append(info.FilterReasons, "not an iOS, macOS or installer code signing certificate")
+		}
+		if !info.HasPrivateKey {
+			info.FilterReasons = append(info.FilterReasons, "no private key in the keychain")
+		} else if !identities.ValidSHA1Fingerprints[strings.ToLower(certificate.SHA1Fingerprint)] {
+			info.FilterReasons = append(info.FilterReasons, "not listed as a valid identity by security find-identity -v (untrusted certificate chain or revoked certificate)")
+		}
+		if now.Before(certificate.StartDate) {
+			info.FilterReasons = append(info.FilterReasons, fmt.Sprintf("not valid yet, validity starts at: %s", certificate.StartDate))
+		}
+		if !now.Before(certificate.EndDate) {
+			info.FilterReasons = append(info.FilterReasons, fmt.Sprintf("expired at: %s", certificate.EndDate))
+		}
+
+		for _, profile := range profiles {
+			for _, profileCertificate := range profile.DeveloperCertificates {
+				if profileCertificate.Serial == certificate.Serial {
+					info.Profiles = append(info.Profiles, fmt.Sprintf("%s (%s)", profile.Name, profile.UUID))
+					break
+				}
+			}
+		}
+
+		infos = append(infos, info)
+		if len(info.FilterReasons) == 0 {
+			nameToCandidates[info.CommonName] = append(nameToCandidates[info.CommonName], len(infos)-1)
+		}
+	}
+
+	// Only one of the certificates with the same name is used, the one expiring first (see: certificateutil.FilterValidCertificateInfos)
+	for _, candidates := range nameToCandidates {
+		if len(candidates) < 2 {
+			continue
+		}
+
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return infos[candidates[i]].ExpirationDate.Before(infos[candidates[j]].ExpirationDate)
+		})
+		used := infos[candidates[0]]
+		for _, idx := range candidates[1:] {
+			infos[idx].FilterReasons = append(infos[idx].FilterReasons, fmt.Sprintf("duplicated name, the certificate expiring first is used: %s", used.Serial))
+		}
+	}
+
+	return infos
+}
+
+// identityKinds returns the certificate kinds the given certificate is used as by InstalledCertificates.
+func identityKinds(certificate certificateutil.CertificateInfoModel) []string {
+	kinds := []string{}
+	if IsInstallerCertificate(certificate) {
+		return append(kinds, IdentityKindInstaller)
+	}
+	if hasCertificateName(certificate, iOSCertificateNames) {
+		kinds = append(kinds, IdentityKindIOS)
+	}
+	if hasCertificateName(certificate, macOSCertificateNames) {
+		kinds = append(kinds, IdentityKindMacOS)
+	}
+	return kinds
+}
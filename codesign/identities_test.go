@@ -0,0 +1,88 @@
+package codesign
+
+import (
+	"testing"
+
+	"github.com/bitrise-io/go-xcode/certificateutil"
+	"github.com/bitrise-io/go-xcode/profileutil"
+	"github.com/stretchr/testify/require"
+)
+
+const findIdentityOutput = `Policy: Code Signing
+  Matching identities
+  1) 1111111111111111111111111111111111111111 "Apple Development: Test User (ABCD1234)"
+  2) 2222222222222222222222222222222222222222 "iPhone Distribution: Test Team (ABCD1234)" (CSSMERR_TP_CERT_EXPIRED)
+     2 identities found
+
+  Valid identities only
+  1) 1111111111111111111111111111111111111111 "Apple Development: Test User (ABCD1234)"
+     1 valid identities found`
+
+func TestParseFindIdentityOutput(t *testing.T) {
+	fingerprints, validFingerprints := map[string]bool{}, map[string]bool{}
+	require.NoError(t, parseFindIdentityOutput(findIdentityOutput, fingerprints, validFingerprints))
+
+	require.Equal(t, map[string]bool{
+		"1111111111111111111111111111111111111111": true,
+		"2222222222222222222222222222222222222222": true,
+	}, fingerprints)
+	require.Equal(t, map[string]bool{"1111111111111111111111111111111111111111": true}, validFingerprints)
+}
+
+func TestFilterIdentityCertificates(t *testing.T) {
+	development := certificateutil.CertificateInfoModel{CommonName: "Apple Development: Test User (ABCD1234)", SHA1Fingerprint: "AA"}
+	withoutKey := certificateutil.CertificateInfoModel{CommonName: "iPhone Distribution: Test Team (ABCD1234)", SHA1Fingerprint: "BB"}
+	otherWithKey := certificateutil.CertificateInfoModel{CommonName: "Test Client", SHA1Fingerprint: "CC"}
+	authority := certificateutil.CertificateInfoModel{CommonName: "Apple Worldwide Developer Relations Certification Authority", SHA1Fingerprint: "DD"}
+
+	filtered := filterIdentityCertificates(
+		[]certificateutil.CertificateInfoModel{development, withoutKey, otherWithKey, authority, development},
+		map[string]bool{"aa": true, "cc": true},
+	)
+	require.Equal(t, []certificateutil.CertificateInfoModel{development, withoutKey, otherWithKey}, filtered)
+}
+
+func TestDescribeIdentities(t *testing.T) {
+	development := "Apple Development: Test User (ABCD1234)"
+	distribution := "iPhone Distribution: Test Team (ABCD1234)"
+
+	identities := KeychainIdentities{
+		Certificates: []certificateutil.CertificateInfoModel{
+			{CommonName: development, Serial: "1", SHA1Fingerprint: "aa", StartDate: createTime(t, "2020.01.01"), EndDate: createTime(t, "2022.01.01")},
+			{CommonName: development, Serial: "2", SHA1Fingerprint: "bb", StartDate: createTime(t, "2020.01.01"), EndDate: createTime(t, "2023.01.01")},
+			{CommonName: distribution, Serial: "3", SHA1Fingerprint: "cc", StartDate: createTime(t, "2019.01.01"), EndDate: createTime(t, "2020.06.01")},
+			{CommonName: "Developer ID Installer: Test Team (ABCD1234)", Serial: "4", SHA1Fingerprint: "dd", StartDate: createTime(t, "2020.01.01"), EndDate: createTime(t, "2023.01.01")},
+			{CommonName: development, Serial: "5", SHA1Fingerprint: "EE", StartDate: createTime(t, "2020.01.01"), EndDate: createTime(t, "2024.01.01")},
+		},
+		PrivateKeySHA1Fingerprints: map[string]bool{"aa": true, "cc": true, "dd": true, "ee": true},
+		ValidSHA1Fingerprints:      map[string]bool{"aa": true, "dd": true, "ee": true},
+	}
+	profiles := []profileutil.ProvisioningProfileInfoModel{
+		{Name: "Development", UUID: "uuid-1", DeveloperCertificates: []certificateutil.CertificateInfoModel{{Serial: "1"}, {Serial: "2"}}},
+		{Name: "Distribution", UUID: "uuid-2", DeveloperCertificates: []certificateutil.CertificateInfoModel{{Serial: "3"}}},
+	}
+
+	infos := DescribeIdentities(identities, profiles, createTime(t, "2021.01.01"))
+	require.Equal(t, 5, len(infos))
+
+	require.Equal(t, []string{IdentityKindIOS, IdentityKindMacOS}, infos[0].Kinds)
+	require.True(t, infos[0].HasPrivateKey)
+	require.Empty(t, infos[0].FilterReasons)
+	require.Equal(t, []string{"Development (uuid-1)"}, infos[0].Profiles)
+
+	require.False(t, infos[1].HasPrivateKey)
+	require.Equal(t, []string{"no private key in the keychain"}, infos[1].FilterReasons)
+
+	require.Equal(t, []string{IdentityKindIOS}, infos[2].Kinds)
+	require.True(t, infos[2].HasPrivateKey)
+	require.Equal(t, 2, len(infos[2].FilterReasons))
+	require.Contains(t, infos[2].FilterReasons[0], "not listed as a valid identity")
+	require.Contains(t, infos[2].FilterReasons[1], "expired at")
+	require.Equal(t, []string{"Distribution (uuid-2)"}, infos[2].Profiles)
+
+	require.Equal(t, []string{IdentityKindInstaller}, infos[3].Kinds)
+	require.Empty(t, infos[3].FilterReasons)
+
+	require.True(t, infos[4].HasPrivateKey)
+	require.Equal(t, []string{"duplicated name, the certificate expiring first is used: 1"}, infos[4].FilterReasons)
+}
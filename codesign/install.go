@@ -0,0 +1,123 @@
+package codesign
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bitrise-io/codesigndoc/models"
+	"github.com/bitrise-io/codesigndoc/osxkeychain"
+	"github.com/bitrise-io/codesigndoc/utility"
+	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/bitrise-io/go-xcode/profileutil"
+)
+
+// passwordFileExtension is appended to the name of a written .p12 file, to store its passphrase next to it.
+const passwordFileExtension = ".password"
+
+// trustedApplicationPaths are the applications allowed to use the installed private keys without a prompt.
+var trustedApplicationPaths = []string{"/usr/bin/codesign", "/usr/bin/security", "/usr/bin/productbuild"}
+
+// WriteDownloadedFiles writes the downloaded identities and provisioning profiles to the output directory.
+// If writePasswords is set, the passphrase of an identities file is written next to it, into a <name>.p12.password file.
+func WriteDownloadedFiles(identities []models.Identities, profiles []models.ProvisioningProfile, absOutputDirPath string, writePasswords bool) error {
+	if err := os.MkdirAll(absOutputDirPath, 0700); err != nil {
+		return fmt.Errorf("failed to create output directory, error: %s", err)
+	}
+
+	usedFileNames := map[string]bool{}
+	for _, identity := range identities {
+		fileName := uniqueFileName(identity.FileName, usedFileNames)
+		pth := filepath.Join(absOutputDirPath, fileName)
+		if err := ioutil.WriteFile(pth, identity.Content, 0600); err != nil {
+			return fmt.Errorf("failed to write file, error: %s", err)
+		}
+		log.Printf("Identities written to: %s", pth)
+
+		if writePasswords && identity.Password != "" {
+			if err := ioutil.WriteFile(pth+passwordFileExtension, []byte(identity.Password), 0600); err != nil {
+				return fmt.Errorf("failed to write file, error: %s", err)
+			}
+			log.Printf("Passphrase written to: %s", pth+passwordFileExtension)
+		}
+	}
+
+	for _, profile := range profiles {
+		pth := filepath.Join(absOutputDirPath, utility.ProfileExportFileNameNoPath(profile.Info))
+		if err := ioutil.WriteFile(pth, profile.Content, 0600); err != nil {
+			return fmt.Errorf("failed to write file, error: %s", err)
+		}
+		log.Printf("Provisioning profile written to: %s", pth)
+	}
+
+	return nil
+}
+
+// InstallCodesigningFiles imports the identities into the default keychain
+// and copies the provisioning profiles into the provisioning profiles directory.
+func InstallCodesigningFiles(identities []models.Identities, profiles []models.ProvisioningProfile) error {
+	for _, identity := range identities {
+		if err := installIdentities(identity); err != nil {
+			return err
+		}
+	}
+
+	absProfilesDirPath, err := pathutil.AbsPath(profileutil.ProvProfileSystemDirPath)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(absProfilesDirPath, 0700); err != nil {
+		return fmt.Errorf("failed to create provisioning profiles directory, error: %s", err)
+	}
+
+	for _, profile := range profiles {
+		pth := filepath.Join(absProfilesDirPath, profileInstallFileName(profile.Info))
+		if err := ioutil.WriteFile(pth, profile.Content, 0600); err != nil {
+			return fmt.Errorf("failed to install provisioning profile (%s), error: %s", profile.Info.Name, err)
+		}
+		log.Printf("Provisioning profile installed: %s (UUID: %s)", profile.Info.Name, profile.Info.UUID)
+	}
+
+	return nil
+}
+
+// installIdentities imports the identities into the default keychain, allowing codesign to use them.
+// The .p12 file is imported in-process, so neither the passphrase nor the decrypted private keys leave the process.
+func installIdentities(identity models.Identities) error {
+	if err := osxkeychain.ImportToKeychain(identity.Content, identity.Password, trustedApplicationPaths); err != nil {
+		if err == osxkeychain.ErrDuplicateItem {
+			log.Warnf("Identities of %s are already in the keychain", identity.FileName)
+			return nil
+		}
+		return fmt.Errorf("failed to import identities (%s) into the keychain, error: %s", identity.FileName, err)
+	}
+
+	for _, certificate := range identity.Info {
+		log.Printf("Identity installed: %s", certificate.CommonName)
+	}
+	return nil
+}
+
+// profileInstallFileName returns the file name of an installed provisioning profile: <UUID>.mobileprovision or <UUID>.provisionprofile.
+func profileInstallFileName(info profileutil.ProvisioningProfileInfoModel) string {
+	if info.Type == profileutil.ProfileTypeMacOs {
+		return info.UUID + ".provisionprofile"
+	}
+	return info.UUID + ".mobileprovision"
+}
+
+// uniqueFileName returns the file name, suffixed with a counter if it is already used, i.e. "Identities-2.p12".
+func uniqueFileName(fileName string, usedFileNames map[string]bool) string {
+	ext := filepath.Ext(fileName)
+	base := strings.TrimSuffix(fileName, ext)
+
+	unique := fileName
+	for i := 2; usedFileNames[unique]; i++ {
+		unique = fmt.Sprintf("%s-%d%s", base, i, ext)
+	}
+	usedFileNames[unique] = true
+	return unique
+}
@@ -0,0 +1,57 @@
+package codesign
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/bitrise-io/codesigndoc/models"
+	"github.com/bitrise-io/go-xcode/profileutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteDownloadedFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	identities := []models.Identities{
+		{FileName: "Identities.p12", Password: "secret", Content: []byte("first")},
+		{FileName: "Identities.p12", Content: []byte("second")},
+	}
+	profiles := []models.ProvisioningProfile{
+		{Info: profileutil.ProvisioningProfileInfoModel{UUID: "uuid-1", Name: "iOS Team Profile: *", Type: profileutil.ProfileTypeIos}, Content: []byte("profile")},
+	}
+	require.NoError(t, WriteDownloadedFiles(identities, profiles, dir, true))
+
+	for name, want := range map[string]string{
+		"Identities.p12":                        "first",
+		"Identities.p12.password":               "secret",
+		"Identities-2.p12":                      "second",
+		"uuid-1.iOSTeamProfile.mobileprovision": "profile",
+	} {
+		content, err := ioutil.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err, name)
+		require.Equal(t, want, string(content), name)
+	}
+
+	_, err := ioutil.ReadFile(filepath.Join(dir, "Identities-2.p12.password"))
+	require.Error(t, err)
+}
+
+func TestWriteDownloadedFiles_NoPasswords(t *testing.T) {
+	dir := t.TempDir()
+
+	identities := []models.Identities{{FileName: "Identities.p12", Password: "secret", Content: []byte("content")}}
+	require.NoError(t, WriteDownloadedFiles(identities, nil, dir, false))
+
+	content, err := ioutil.ReadFile(filepath.Join(dir, "Identities.p12"))
+	require.NoError(t, err)
+	require.Equal(t, "content", string(content))
+
+	_, err = ioutil.ReadFile(filepath.Join(dir, "Identities.p12.password"))
+	require.Error(t, err)
+}
+
+func TestProfileInstallFileName(t *testing.T) {
+	require.Equal(t, "uuid-1.mobileprovision", profileInstallFileName(profileutil.ProvisioningProfileInfoModel{UUID: "uuid-1", Type: profileutil.ProfileTypeIos}))
+	require.Equal(t, "uuid-2.provisionprofile", profileInstallFileName(profileutil.ProvisioningProfileInfoModel{UUID: "uuid-2", Type: profileutil.ProfileTypeMacOs}))
+}
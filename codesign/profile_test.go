@@ -54,3 +54,28 @@ func TestFilterLatestProfiles(t *testing.T) {
 		require.True(t, found)
 	}
 }
+
+func TestFilterProfilesByTeam(t *testing.T) {
+	profiles := []profileutil.ProvisioningProfileInfoModel{
+		{UUID: "uuid-1", TeamID: "TEAMA"},
+		{UUID: "uuid-2", TeamID: "TEAMB"},
+		{UUID: "uuid-3", TeamID: "TEAMA"},
+	}
+
+	require.Equal(t, profiles, FilterProfilesByTeam(profiles, ""))
+
+	filtered := FilterProfilesByTeam(profiles, "TEAMA")
+	require.Equal(t, 2, len(filtered))
+	require.Equal(t, "uuid-1", filtered[0].UUID)
+	require.Equal(t, "uuid-3", filtered[1].UUID)
+
+	require.Empty(t, FilterProfilesByTeam(profiles, "TEAMC"))
+}
+
+func TestMergeProfiles(t *testing.T) {
+	app := []profileutil.ProvisioningProfileInfoModel{{UUID: "uuid-1"}, {UUID: "uuid-2"}}
+	uiTests := []profileutil.ProvisioningProfileInfoModel{{UUID: "uuid-2"}, {UUID: "uuid-3"}}
+
+	require.Equal(t, []profileutil.ProvisioningProfileInfoModel{{UUID: "uuid-1"}, {UUID: "uuid-2"}, {UUID: "uuid-3"}}, MergeProfiles(app, uiTests))
+	require.Empty(t, MergeProfiles(nil, nil))
+}
@@ -0,0 +1,87 @@
+package codesign
+
+import (
+	"time"
+
+	"github.com/bitrise-io/go-xcode/certificateutil"
+	"github.com/bitrise-io/go-xcode/profileutil"
+)
+
+// ProfileInspection is the printable summary of a provisioning profile.
+type ProfileInspection struct {
+	Name                 string                  `json:"name"`
+	UUID                 string                  `json:"uuid"`
+	TeamName             string                  `json:"team_name"`
+	TeamID               string                  `json:"team_id"`
+	AppID                string                  `json:"app_id"`
+	BundleID             string                  `json:"bundle_id"`
+	Type                 string                  `json:"type"`
+	ExportMethod         string                  `json:"export_method"`
+	IsXcodeManaged       bool                    `json:"is_xcode_managed"`
+	CreationDate         time.Time               `json:"creation_date"`
+	ExpirationDate       time.Time               `json:"expiration_date"`
+	IsExpired            bool                    `json:"is_expired"`
+	ProvisionsAllDevices bool                    `json:"provisions_all_devices"`
+	ProvisionedDevices   int                     `json:"provisioned_devices_count"`
+	Certificates         []CertificateInspection `json:"certificates"`
+	Entitlements         map[string]interface{}  `json:"entitlements"`
+}
+
+// CertificateInspection is the printable summary of a certificate included in a provisioning profile.
+type CertificateInspection struct {
+	CommonName      string    `json:"common_name"`
+	Serial          string    `json:"serial"`
+	SHA1Fingerprint string    `json:"sha1_fingerprint"`
+	ExpirationDate  time.Time `json:"expiration_date"`
+	IsInstalled     bool      `json:"is_installed"`
+}
+
+// InspectProfile returns the summary of the given provisioning profile,
+// the profile's certificates are looked up by serial in the installed certificates.
+func InspectProfile(profile profileutil.ProvisioningProfileInfoModel, installedCertificates []certificateutil.CertificateInfoModel, now time.Time) ProfileInspection {
+	installedSerials := map[string]bool{}
+	for _, certificate := range installedCertificates {
+		installedSerials[certificate.Serial] = true
+	}
+
+	inspection := ProfileInspection{
+		Name:                 profile.Name,
+		UUID:                 profile.UUID,
+		TeamName:             profile.TeamName,
+		TeamID:               profile.TeamID,
+		AppID:                profileAppID(profile),
+		BundleID:             profile.BundleID,
+		Type:                 string(profile.Type),
+		ExportMethod:         string(profile.ExportType),
+		IsXcodeManaged:       profile.IsXcodeManaged(),
+		CreationDate:         profile.CreationDate,
+		ExpirationDate:       profile.ExpirationDate,
+		IsExpired:            !now.Before(profile.ExpirationDate),
+		ProvisionsAllDevices: profile.ProvisionsAllDevices,
+		ProvisionedDevices:   len(profile.ProvisionedDevices),
+		Certificates:         []CertificateInspection{},
+		Entitlements:         map[string]interface{}(profile.Entitlements),
+	}
+
+	for _, certificate := range profile.DeveloperCertificates {
+		inspection.Certificates = append(inspection.Certificates, CertificateInspection{
+			CommonName:      certificate.CommonName,
+			Serial:          certificate.Serial,
+			SHA1Fingerprint: certificate.SHA1Fingerprint,
+			ExpirationDate:  certificate.EndDate,
+			IsInstalled:     installedSerials[certificate.Serial],
+		})
+	}
+
+	return inspection
+}
+
+// profileAppID returns the application identifier (team ID prefixed bundle ID) of the given profile.
+func profileAppID(profile profileutil.ProvisioningProfileInfoModel) string {
+	for _, key := range []string{"application-identifier", "com.apple.application-identifier"} {
+		if appID, ok := profile.Entitlements.GetString(key); ok {
+			return appID
+		}
+	}
+	return ""
+}
@@ -0,0 +1,46 @@
+package codesign
+
+import (
+	"testing"
+
+	"github.com/bitrise-io/go-xcode/certificateutil"
+	"github.com/bitrise-io/go-xcode/exportoptions"
+	"github.com/bitrise-io/go-xcode/plistutil"
+	"github.com/bitrise-io/go-xcode/profileutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspectProfile(t *testing.T) {
+	profile := profileutil.ProvisioningProfileInfoModel{
+		Name:               "XC iOS: io.bitrise.app",
+		UUID:               "uuid-1",
+		TeamName:           "Bitrise",
+		TeamID:             "TEAMA",
+		BundleID:           "io.bitrise.app",
+		ExportType:         exportoptions.MethodDevelopment,
+		Type:               profileutil.ProfileTypeIos,
+		ProvisionedDevices: []string{"device-1", "device-2"},
+		ExpirationDate:     createTime(t, "2030.01.01"),
+		DeveloperCertificates: []certificateutil.CertificateInfoModel{
+			{CommonName: "Apple Development: Test User", Serial: "1", SHA1Fingerprint: "sha1-1"},
+			{CommonName: "Apple Development: Other User", Serial: "2", SHA1Fingerprint: "sha1-2"},
+		},
+		Entitlements: plistutil.PlistData{"application-identifier": "TEAMA.io.bitrise.app"},
+	}
+	installedCertificates := []certificateutil.CertificateInfoModel{{Serial: "2"}}
+
+	inspection := InspectProfile(profile, installedCertificates, createTime(t, "2031.01.01"))
+
+	require.Equal(t, "TEAMA.io.bitrise.app", inspection.AppID)
+	require.Equal(t, "development", inspection.ExportMethod)
+	require.Equal(t, "ios", inspection.Type)
+	require.True(t, inspection.IsXcodeManaged)
+	require.True(t, inspection.IsExpired)
+	require.Equal(t, 2, inspection.ProvisionedDevices)
+	require.Equal(t, []CertificateInspection{
+		{CommonName: "Apple Development: Test User", Serial: "1", SHA1Fingerprint: "sha1-1", IsInstalled: false},
+		{CommonName: "Apple Development: Other User", Serial: "2", SHA1Fingerprint: "sha1-2", IsInstalled: true},
+	}, inspection.Certificates)
+
+	require.False(t, InspectProfile(profile, nil, createTime(t, "2029.01.01")).IsExpired)
+}
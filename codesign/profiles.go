@@ -31,3 +31,35 @@ func FilterLatestProfiles(profiles []profileutil.ProvisioningProfileInfoModel) [
 	}
 	return filteredProfiles
 }
+
+// FilterProfilesByTeam returns the provisioning profiles of the given team,
+// all profiles are returned if the teamID is empty.
+func FilterProfilesByTeam(profiles []profileutil.ProvisioningProfileInfoModel, teamID string) []profileutil.ProvisioningProfileInfoModel {
+	if teamID == "" {
+		return profiles
+	}
+
+	var filteredProfiles []profileutil.ProvisioningProfileInfoModel
+	for _, profile := range profiles {
+		if profile.TeamID == teamID {
+			filteredProfiles = append(filteredProfiles, profile)
+		}
+	}
+	return filteredProfiles
+}
+
+// MergeProfiles returns the provisioning profiles of the given lists, without duplicates (by UUID).
+func MergeProfiles(profileLists ...[]profileutil.ProvisioningProfileInfoModel) []profileutil.ProvisioningProfileInfoModel {
+	var merged []profileutil.ProvisioningProfileInfoModel
+	uuids := map[string]bool{}
+	for _, profiles := range profileLists {
+		for _, profile := range profiles {
+			if uuids[profile.UUID] {
+				continue
+			}
+			uuids[profile.UUID] = true
+			merged = append(merged, profile)
+		}
+	}
+	return merged
+}
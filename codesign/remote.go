@@ -0,0 +1,165 @@
+package codesign
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bitrise-io/codesigndoc/bitriseio"
+	"github.com/bitrise-io/codesigndoc/bitriseio/bitrise"
+	"github.com/bitrise-io/codesigndoc/models"
+	"github.com/bitrise-io/go-utils/log"
+)
+
+// CopyReport describes the result of copying code signing files between Bitrise apps.
+type CopyReport struct {
+	IdentitiesCopied            int
+	IdentitiesSkipped           int
+	ProvisioningProfilesCopied  int
+	ProvisioningProfilesSkipped int
+}
+
+// CopyCodesigningFiles copies the identities and provisioning profiles uploaded to the source app to the target app.
+// If file names or slugs are given, only the matching files are copied, otherwise every file.
+// The identities whose every certificate is on the target app (by serial) and the provisioning profiles on the target app (by UUID)
+// are skipped, as are the protected files, which can not be downloaded.
+// The copied files keep whether they are protected or exposed to pull request builds, isProtected uploads every copied file as protected.
+func CopyCodesigningFiles(source, target bitrise.API, files []string, isProtected bool) (CopyReport, error) {
+	identityList, err := source.FetchUploadedIdentities()
+	if err != nil {
+		return CopyReport{}, fmt.Errorf("failed to list the identities of the source app, error: %s", err)
+	}
+	profileList, err := source.FetchProvisioningProfiles()
+	if err != nil {
+		return CopyReport{}, fmt.Errorf("failed to list the provisioning profiles of the source app, error: %s", err)
+	}
+
+	identityList, profileList, err = selectFilesToCopy(identityList, profileList, files)
+	if err != nil {
+		return CopyReport{}, err
+	}
+
+	var report CopyReport
+
+	fmt.Println()
+	log.Infof("Copying certificates...")
+	uploadedSerials, err := bitriseio.UploadedCertificateSerials(target)
+	if err != nil {
+		return report, fmt.Errorf("failed to list the certificates of the target app, error: %s", err)
+	}
+
+	for _, identityData := range identityList {
+		if identityData.IsProtected {
+			log.Warnf("Skipping protected %s (slug: %s), protected files can not be downloaded", identityData.UploadFileName, identityData.Slug)
+			report.IdentitiesSkipped++
+			continue
+		}
+
+		identity, err := bitriseio.DownloadIdentity(source, identityData)
+		if err != nil {
+			return report, err
+		}
+
+		if !bitriseio.HasNewCertificate(uploadedSerials, identity.Info) {
+			log.Warnf("Already on the target app: %s (slug: %s)", identityData.UploadFileName, identityData.Slug)
+			report.IdentitiesSkipped++
+			continue
+		}
+
+		if err := bitriseio.UploadIdentity(target, identity.Content, bitriseio.UploadOptions{
+			P12Password:         identity.Password,
+			IdentitiesProtected: identityData.IsProtected || isProtected,
+			IdentitiesExposed:   identityData.IsExpose,
+		}); err != nil {
+			return report, err
+		}
+		for _, certificate := range identity.Info {
+			uploadedSerials[certificate.Serial] = true
+		}
+		report.IdentitiesCopied++
+	}
+
+	fmt.Println()
+	log.Infof("Copying provisioning profiles...")
+	var profiles []models.ProvisioningProfile
+	protectedProfiles := map[string]bool{}
+	exposedProfiles := map[string]bool{}
+	for _, profileData := range profileList {
+		if profileData.IsProtected {
+			log.Warnf("Skipping protected %s (slug: %s), protected files can not be downloaded", profileData.UploadFileName, profileData.Slug)
+			report.ProvisioningProfilesSkipped++
+			continue
+		}
+
+		profile, err := bitriseio.DownloadProvisioningProfile(source, profileData)
+		if err != nil {
+			return report, err
+		}
+		profiles = append(profiles, profile)
+		protectedProfiles[profile.Info.UUID] = profileData.IsProtected || isProtected
+		exposedProfiles[profile.Info.UUID] = profileData.IsExpose
+	}
+
+	profilesToUpload, err := bitriseio.FilterAlreadyUploadedProvProfiles(target, profiles)
+	if err != nil {
+		return report, err
+	}
+	report.ProvisioningProfilesSkipped += len(profiles) - len(profilesToUpload)
+
+	for _, profile := range profilesToUpload {
+		if err := bitriseio.UploadProvisioningProfiles(target, []models.ProvisioningProfile{profile}, bitriseio.UploadOptions{
+			ProfilesProtected: protectedProfiles[profile.Info.UUID],
+			ProfilesExposed:   exposedProfiles[profile.Info.UUID],
+		}); err != nil {
+			return report, err
+		}
+		report.ProvisioningProfilesCopied++
+	}
+
+	return report, nil
+}
+
+// selectFilesToCopy returns the identities and provisioning profiles matching any of the given file names or slugs,
+// or every file if none is given. It fails if a given file name or slug matches no file.
+func selectFilesToCopy(identities []bitrise.IdentityListData, profiles []bitrise.ProvisioningProfileListData, files []string) ([]bitrise.IdentityListData, []bitrise.ProvisioningProfileListData, error) {
+	if len(files) == 0 {
+		return identities, profiles, nil
+	}
+
+	matched := map[string]bool{}
+	isSelected := func(fileName, slug string) bool {
+		selected := false
+		for _, file := range files {
+			if file == fileName || file == slug {
+				matched[file] = true
+				selected = true
+			}
+		}
+		return selected
+	}
+
+	var selectedIdentities []bitrise.IdentityListData
+	for _, identity := range identities {
+		if isSelected(identity.UploadFileName, identity.Slug) {
+			selectedIdentities = append(selectedIdentities, identity)
+		}
+	}
+
+	var selectedProfiles []bitrise.ProvisioningProfileListData
+	for _, profile := range profiles {
+		if isSelected(profile.UploadFileName, profile.Slug) {
+			selectedProfiles = append(selectedProfiles, profile)
+		}
+	}
+
+	var notFound []string
+	for _, file := range files {
+		if !matched[file] {
+			notFound = append(notFound, file)
+		}
+	}
+	if len(notFound) > 0 {
+		return nil, nil, fmt.Errorf("no file found on the source app with name or slug: %s", strings.Join(notFound, ", "))
+	}
+
+	return selectedIdentities, selectedProfiles, nil
+}
@@ -0,0 +1,155 @@
+package codesign
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/bitrise-io/codesigndoc/bitriseio/bitrise"
+	"github.com/bitrise-io/go-xcode/certificateutil"
+	"github.com/bitrise-io/pkcs12"
+	"github.com/fullsailor/pkcs7"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRemoteApp serves the files uploaded to a Bitrise app by slug, the other calls of the API (the uploads) panic.
+type fakeRemoteApp struct {
+	bitrise.API
+	identities    []bitrise.IdentityListData
+	identityFiles map[string][]byte
+	serials       map[string][]big.Int
+	profiles      []bitrise.ProvisioningProfileListData
+	profileFiles  map[string][]byte
+	uuids         map[string]string
+}
+
+func (app fakeRemoteApp) FetchUploadedIdentities() ([]bitrise.IdentityListData, error) {
+	return app.identities, nil
+}
+
+func (app fakeRemoteApp) DownloadIdentity(identitySlug string) ([]byte, string, error) {
+	content, ok := app.identityFiles[identitySlug]
+	if !ok {
+		return nil, "", fmt.Errorf("can not download identity: %s", identitySlug)
+	}
+	return content, testP12Password, nil
+}
+
+func (app fakeRemoteApp) GetUploadedCertificatesSerialby(identitySlug string) ([]big.Int, error) {
+	serials, ok := app.serials[identitySlug]
+	if !ok {
+		return nil, fmt.Errorf("can not download identity: %s", identitySlug)
+	}
+	return serials, nil
+}
+
+func (app fakeRemoteApp) FetchProvisioningProfiles() ([]bitrise.ProvisioningProfileListData, error) {
+	return app.profiles, nil
+}
+
+func (app fakeRemoteApp) DownloadProvisioningProfile(profileSlug string) ([]byte, error) {
+	content, ok := app.profileFiles[profileSlug]
+	if !ok {
+		return nil, fmt.Errorf("can not download provisioning profile: %s", profileSlug)
+	}
+	return content, nil
+}
+
+func (app fakeRemoteApp) GetUploadedProvisioningProfileUUIDby(profileSlug string) (string, error) {
+	uuid, ok := app.uuids[profileSlug]
+	if !ok {
+		return "", fmt.Errorf("can not download provisioning profile: %s", profileSlug)
+	}
+	return uuid, nil
+}
+
+const testP12Password = "secret"
+
+// testIdentityAndProfile returns a .p12 file of a test certificate with the given serial,
+// and a provisioning profile with the given UUID signed by the certificate.
+func testIdentityAndProfile(t *testing.T, serial int64, uuid string) ([]byte, []byte) {
+	cert, key, err := certificateutil.GenerateTestCertificate(serial, "ABCD1234", "Bitrise", "Apple Development: Test User (ABCD1234)", time.Now().AddDate(1, 0, 0))
+	require.NoError(t, err)
+
+	identity, err := pkcs12.Encode(rand.Reader, key, cert, nil, testP12Password)
+	require.NoError(t, err)
+
+	content := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Name</key>
+	<string>Development</string>
+	<key>Platform</key>
+	<array>
+		<string>iOS</string>
+	</array>
+	<key>UUID</key>
+	<string>` + uuid + `</string>
+</dict>
+</plist>`)
+	signedData, err := pkcs7.NewSignedData(content)
+	require.NoError(t, err)
+	require.NoError(t, signedData.AddSigner(cert, key, pkcs7.SignerInfoConfig{}))
+	profile, err := signedData.Finish()
+	require.NoError(t, err)
+
+	return identity, profile
+}
+
+func TestCopyCodesigningFiles_SkipsProtectedFiles(t *testing.T) {
+	source := fakeRemoteApp{
+		identities: []bitrise.IdentityListData{{Slug: "identity-1", UploadFileName: "Identities.p12", IsProtected: true}},
+		profiles:   []bitrise.ProvisioningProfileListData{{Slug: "profile-1", UploadFileName: "Development.mobileprovision", IsProtected: true}},
+	}
+
+	report, err := CopyCodesigningFiles(source, fakeRemoteApp{}, nil, false)
+	require.NoError(t, err)
+	require.Equal(t, CopyReport{IdentitiesSkipped: 1, ProvisioningProfilesSkipped: 1}, report)
+}
+
+func TestCopyCodesigningFiles_SkipsFilesOnTheTargetApp(t *testing.T) {
+	identity, profile := testIdentityAndProfile(t, 1, "uuid-1")
+	source := fakeRemoteApp{
+		identities:    []bitrise.IdentityListData{{Slug: "identity-1", UploadFileName: "Identities.p12"}},
+		identityFiles: map[string][]byte{"identity-1": identity},
+		profiles:      []bitrise.ProvisioningProfileListData{{Slug: "profile-1", UploadFileName: "Development.mobileprovision"}},
+		profileFiles:  map[string][]byte{"profile-1": profile},
+	}
+	target := fakeRemoteApp{
+		identities: []bitrise.IdentityListData{{Slug: "identity-2", UploadFileName: "Development.p12"}},
+		serials:    map[string][]big.Int{"identity-2": {*big.NewInt(1)}},
+		profiles:   []bitrise.ProvisioningProfileListData{{Slug: "profile-2", UploadFileName: "Development.mobileprovision"}},
+		uuids:      map[string]string{"profile-2": "uuid-1"},
+	}
+
+	report, err := CopyCodesigningFiles(source, target, nil, false)
+	require.NoError(t, err)
+	require.Equal(t, CopyReport{IdentitiesSkipped: 1, ProvisioningProfilesSkipped: 1}, report)
+}
+
+func TestSelectFilesToCopy(t *testing.T) {
+	identities := []bitrise.IdentityListData{
+		{Slug: "identity-1", UploadFileName: "Identities.p12"},
+		{Slug: "identity-2", UploadFileName: "Identities.p12"},
+	}
+	profiles := []bitrise.ProvisioningProfileListData{
+		{Slug: "profile-1", UploadFileName: "Development.mobileprovision"},
+		{Slug: "profile-2", UploadFileName: "AppStore.mobileprovision"},
+	}
+
+	selectedIdentities, selectedProfiles, err := selectFilesToCopy(identities, profiles, nil)
+	require.NoError(t, err)
+	require.Equal(t, identities, selectedIdentities)
+	require.Equal(t, profiles, selectedProfiles)
+
+	selectedIdentities, selectedProfiles, err = selectFilesToCopy(identities, profiles, []string{"identity-2", "AppStore.mobileprovision"})
+	require.NoError(t, err)
+	require.Equal(t, identities[1:], selectedIdentities)
+	require.Equal(t, profiles[1:], selectedProfiles)
+
+	_, _, err = selectFilesToCopy(identities, profiles, []string{"Identities.p12", "missing", "profile-3"})
+	require.EqualError(t, err, "no file found on the source app with name or slug: missing, profile-3")
+}
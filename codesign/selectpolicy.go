@@ -0,0 +1,142 @@
+package codesign
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-io/go-xcode/certificateutil"
+	"github.com/bitrise-io/go-xcode/profileutil"
+)
+
+type selectPolicyKind string
+
+const (
+	selectPolicyNone               selectPolicyKind = ""
+	selectPolicyNewestExpiry       selectPolicyKind = "newest-expiry"
+	selectPolicyPreferManual       selectPolicyKind = "prefer-manual"
+	selectPolicyPreferXcodeManaged selectPolicyKind = "prefer-xcode-managed"
+	selectPolicyTeam               selectPolicyKind = "team"
+)
+
+// SelectPolicy decides which certificate or provisioning profile to use
+// when more than one of them qualifies, instead of asking the user.
+type SelectPolicy struct {
+	kind   selectPolicyKind
+	teamID string
+}
+
+// ParseSelectPolicy parses the given select policy,
+// valid values: newest-expiry, prefer-manual, prefer-xcode-managed, team=<ID>.
+// An empty string results in an unset policy.
+func ParseSelectPolicy(policy string) (SelectPolicy, error) {
+	switch kind := selectPolicyKind(policy); kind {
+	case selectPolicyNone, selectPolicyNewestExpiry, selectPolicyPreferManual, selectPolicyPreferXcodeManaged:
+		return SelectPolicy{kind: kind}, nil
+	}
+
+	if strings.HasPrefix(policy, string(selectPolicyTeam)+"=") {
+		teamID := strings.TrimSpace(strings.TrimPrefix(policy, string(selectPolicyTeam)+"="))
+		if teamID == "" {
+			return SelectPolicy{}, fmt.Errorf("missing team ID in select policy: %s", policy)
+		}
+		return SelectPolicy{kind: selectPolicyTeam, teamID: teamID}, nil
+	}
+
+	return SelectPolicy{}, fmt.Errorf("invalid select policy: %s, valid values: 'newest-expiry', 'prefer-manual', 'prefer-xcode-managed', 'team=<ID>'", policy)
+}
+
+// IsSet returns true if a select policy was provided.
+func (policy SelectPolicy) IsSet() bool {
+	return policy.kind != selectPolicyNone
+}
+
+// String ...
+func (policy SelectPolicy) String() string {
+	if policy.kind == selectPolicyTeam {
+		return fmt.Sprintf("%s=%s", policy.kind, policy.teamID)
+	}
+	return string(policy.kind)
+}
+
+// SelectCertificate selects one of the given certificates according to the policy.
+// Certificates of the policy's team are preferred if a team policy is set,
+// the certificate with the latest expiry is selected from the remaining candidates.
+func (policy SelectPolicy) SelectCertificate(certificates []certificateutil.CertificateInfoModel) (certificateutil.CertificateInfoModel, error) {
+	candidates := append([]certificateutil.CertificateInfoModel{}, certificates...)
+	if policy.kind == selectPolicyTeam {
+		candidates = certificateutil.FilterCertificateInfoModelsByFilterFunc(certificates, func(certificate certificateutil.CertificateInfoModel) bool {
+			return certificate.TeamID == policy.teamID
+		})
+	}
+	if len(candidates) == 0 {
+		return certificateutil.CertificateInfoModel{}, fmt.Errorf("select policy (%s): none of the %d certificates qualifies", policy, len(certificates))
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if !candidates[i].EndDate.Equal(candidates[j].EndDate) {
+			return candidates[i].EndDate.After(candidates[j].EndDate)
+		}
+		return candidates[i].Serial < candidates[j].Serial
+	})
+	selected := candidates[0]
+
+	log.Printf("Select policy (%s) selected certificate: %s [%s], expires: %s", policy, selected.CommonName, selected.Serial, selected.EndDate)
+	for _, certificate := range certificates {
+		if certificate.Serial != selected.Serial {
+			log.Printf("- rejected: %s [%s] - team: %s, expires: %s", certificate.CommonName, certificate.Serial, certificate.TeamID, certificate.EndDate)
+		}
+	}
+
+	return selected, nil
+}
+
+// SelectProfile selects one of the given provisioning profiles according to the policy.
+// Manual or Xcode managed profiles, or profiles of the policy's team are preferred depending on the policy,
+// the profile with the latest expiry is selected from the remaining candidates.
+func (policy SelectPolicy) SelectProfile(profiles []profileutil.ProvisioningProfileInfoModel) (profileutil.ProvisioningProfileInfoModel, error) {
+	var candidates []profileutil.ProvisioningProfileInfoModel
+	for _, profile := range profiles {
+		switch policy.kind {
+		case selectPolicyPreferManual:
+			if profile.IsXcodeManaged() {
+				continue
+			}
+		case selectPolicyPreferXcodeManaged:
+			if !profile.IsXcodeManaged() {
+				continue
+			}
+		case selectPolicyTeam:
+			if profile.TeamID != policy.teamID {
+				continue
+			}
+		}
+		candidates = append(candidates, profile)
+	}
+
+	// prefer-* policies are preferences only, fall back to every profile if none is preferred.
+	if len(candidates) == 0 && (policy.kind == selectPolicyPreferManual || policy.kind == selectPolicyPreferXcodeManaged) {
+		candidates = append(candidates, profiles...)
+	}
+	if len(candidates) == 0 {
+		return profileutil.ProvisioningProfileInfoModel{}, fmt.Errorf("select policy (%s): none of the %d provisioning profiles qualifies", policy, len(profiles))
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if !candidates[i].ExpirationDate.Equal(candidates[j].ExpirationDate) {
+			return candidates[i].ExpirationDate.After(candidates[j].ExpirationDate)
+		}
+		return candidates[i].UUID < candidates[j].UUID
+	})
+	selected := candidates[0]
+
+	log.Printf("Select policy (%s) selected provisioning profile: %s (%s), expires: %s", policy, selected.Name, selected.UUID, selected.ExpirationDate)
+	for _, profile := range profiles {
+		if profile.UUID != selected.UUID {
+			log.Printf("- rejected: %s (%s) - team: %s, xcode managed: %v, expires: %s", profile.Name, profile.UUID, profile.TeamID, profile.IsXcodeManaged(), profile.ExpirationDate)
+		}
+	}
+
+	return selected, nil
+}
@@ -0,0 +1,99 @@
+package codesign
+
+import (
+	"testing"
+
+	"github.com/bitrise-io/go-xcode/certificateutil"
+	"github.com/bitrise-io/go-xcode/profileutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSelectPolicy(t *testing.T) {
+	for _, value := range []string{"", "newest-expiry", "prefer-manual", "prefer-xcode-managed", "team=ABCD1234"} {
+		policy, err := ParseSelectPolicy(value)
+		require.NoError(t, err, value)
+		require.Equal(t, value, policy.String())
+		require.Equal(t, value != "", policy.IsSet())
+	}
+
+	for _, value := range []string{"newest", "team=", "team"} {
+		_, err := ParseSelectPolicy(value)
+		require.Error(t, err, value)
+	}
+}
+
+func TestSelectPolicy_SelectCertificate(t *testing.T) {
+	certificates := []certificateutil.CertificateInfoModel{
+		{CommonName: "Apple Distribution: Team A", Serial: "1", TeamID: "TEAMA", EndDate: createTime(t, "2030.01.01")},
+		{CommonName: "Apple Distribution: Team B", Serial: "2", TeamID: "TEAMB", EndDate: createTime(t, "2031.01.01")},
+		{CommonName: "Apple Distribution: Team A", Serial: "3", TeamID: "TEAMA", EndDate: createTime(t, "2029.01.01")},
+	}
+
+	policy, err := ParseSelectPolicy("newest-expiry")
+	require.NoError(t, err)
+	selected, err := policy.SelectCertificate(certificates)
+	require.NoError(t, err)
+	require.Equal(t, "2", selected.Serial)
+
+	policy, err = ParseSelectPolicy("team=TEAMA")
+	require.NoError(t, err)
+	selected, err = policy.SelectCertificate(certificates)
+	require.NoError(t, err)
+	require.Equal(t, "1", selected.Serial)
+
+	policy, err = ParseSelectPolicy("team=TEAMC")
+	require.NoError(t, err)
+	_, err = policy.SelectCertificate(certificates)
+	require.Error(t, err)
+
+	require.Equal(t, "1", certificates[0].Serial, "input must not be reordered")
+}
+
+func TestSelectPolicy_SingleCandidateOfAnotherTeam(t *testing.T) {
+	policy, err := ParseSelectPolicy("team=TEAMA")
+	require.NoError(t, err)
+
+	_, err = policy.SelectCertificate([]certificateutil.CertificateInfoModel{
+		{CommonName: "Apple Distribution: Team B", Serial: "2", TeamID: "TEAMB", EndDate: createTime(t, "2031.01.01")},
+	})
+	require.Error(t, err)
+
+	_, err = policy.SelectProfile([]profileutil.ProvisioningProfileInfoModel{
+		{Name: "io.bitrise.app AppStore B", UUID: "uuid-3", TeamID: "TEAMB", ExpirationDate: createTime(t, "2029.01.01")},
+	})
+	require.Error(t, err)
+}
+
+func TestSelectPolicy_SelectProfile(t *testing.T) {
+	profiles := []profileutil.ProvisioningProfileInfoModel{
+		{Name: "XC iOS: io.bitrise.app", UUID: "uuid-1", TeamID: "TEAMA", ExpirationDate: createTime(t, "2031.01.01")},
+		{Name: "io.bitrise.app AppStore", UUID: "uuid-2", TeamID: "TEAMA", ExpirationDate: createTime(t, "2030.01.01")},
+		{Name: "io.bitrise.app AppStore B", UUID: "uuid-3", TeamID: "TEAMB", ExpirationDate: createTime(t, "2029.01.01")},
+	}
+
+	tests := []struct {
+		policy string
+		want   string
+	}{
+		{policy: "newest-expiry", want: "uuid-1"},
+		{policy: "prefer-manual", want: "uuid-2"},
+		{policy: "prefer-xcode-managed", want: "uuid-1"},
+		{policy: "team=TEAMB", want: "uuid-3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			policy, err := ParseSelectPolicy(tt.policy)
+			require.NoError(t, err)
+
+			selected, err := policy.SelectProfile(profiles)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, selected.UUID)
+		})
+	}
+
+	policy, err := ParseSelectPolicy("prefer-manual")
+	require.NoError(t, err)
+	selected, err := policy.SelectProfile(profiles[:1])
+	require.NoError(t, err)
+	require.Equal(t, "uuid-1", selected.UUID, "prefer-manual falls back to xcode managed profiles")
+}
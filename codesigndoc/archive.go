@@ -1,8 +1,11 @@
 package codesigndoc
 
 import (
+	"fmt"
+
 	"github.com/bitrise-io/go-xcode/plistutil"
 	"github.com/bitrise-io/go-xcode/profileutil"
+	"github.com/bitrise-io/go-xcode/xcarchive"
 )
 
 // Archive ...
@@ -12,3 +15,25 @@ type Archive interface {
 	SigningIdentity() string
 	BundleIDProfileInfoMap() map[string]profileutil.ProvisioningProfileInfoModel
 }
+
+// OpenArchive opens the iOS or macOS archive at the given path. It also returns whether it is a macOS archive.
+func OpenArchive(archivePath string) (Archive, bool, error) {
+	isMacOS, err := xcarchive.IsMacOS(archivePath)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if isMacOS {
+		archive, err := xcarchive.NewMacosArchive(archivePath)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to analyze archive, error: %s", err)
+		}
+		return archive, true, nil
+	}
+
+	archive, err := xcarchive.NewIosArchive(archivePath)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to analyze archive, error: %s", err)
+	}
+	return archive, false, nil
+}
@@ -20,12 +20,16 @@ const collectCodesigningFilesInfo = `To collect available code sign files, we se
 `
 
 // CollectCodesignFiles collects the codesigning files required to create an xcode archive
-// and filers them for the specified export method.
-func CollectCodesignFiles(archivePath string, certificatesOnly bool) ([]certificateutil.CertificateInfoModel, []profileutil.ProvisioningProfileInfoModel, error) {
+// and filers them for the specified export methods.
+// If exportMethods is empty, the export methods are asked interactively.
+// The selectPolicy is used to choose from multiple qualifying certificates or profiles, if set.
+// If teamID is not empty, only the given team's code signing files are collected for the export.
+// The export options of the collected export methods are returned too, none if certificatesOnly is set.
+func CollectCodesignFiles(archivePath string, certificatesOnly bool, exportMethods []string, selectPolicy codesign.SelectPolicy, teamID string) ([]certificateutil.CertificateInfoModel, []profileutil.ProvisioningProfileInfoModel, []codesign.ExportOptions, error) {
 	// Find out the XcArchive type
 	isMacOs, err := xcarchive.IsMacOS(archivePath)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	// Set up the XcArchive type for certs and profiles.
@@ -39,12 +43,12 @@ func CollectCodesignFiles(archivePath string, certificatesOnly bool) ([]certific
 	// Certificates
 	certificates, err := codesign.InstalledCertificates(certificateType)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to list installed code signing identities, error: %s", err)
+		return nil, nil, nil, fmt.Errorf("failed to list installed code signing identities, error: %s", err)
 	}
 
 	installerCertificates, err := certificateutil.InstalledInstallerCertificateInfos()
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to list installed code signing identities, error: %s", err)
+		return nil, nil, nil, fmt.Errorf("failed to list installed code signing identities, error: %s", err)
 	}
 
 	log.Debugf("Installed certificates:")
@@ -55,7 +59,7 @@ func CollectCodesignFiles(archivePath string, certificatesOnly bool) ([]certific
 	// Profiles
 	profiles, err := profileutil.InstalledProvisioningProfileInfos(profileType)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to list installed provisioning profiles, error: %s", err)
+		return nil, nil, nil, fmt.Errorf("failed to list installed provisioning profiles, error: %s", err)
 	}
 
 	log.Debugf("Installed profiles:")
@@ -67,13 +71,13 @@ func CollectCodesignFiles(archivePath string, certificatesOnly bool) ([]certific
 	fmt.Println()
 	fmt.Println()
 	log.Printf("🔦  Analyzing the archive, to get export code signing settings...")
-	return getFilesToExport(archivePath, certificates, installerCertificates, profiles, certificatesOnly)
+	return getFilesToExport(archivePath, certificates, installerCertificates, profiles, certificatesOnly, exportMethods, selectPolicy, teamID)
 }
 
-func getFilesToExport(archivePath string, installedCertificates []certificateutil.CertificateInfoModel, installedInstallerCertificates []certificateutil.CertificateInfoModel, installedProfiles []profileutil.ProvisioningProfileInfoModel, certificatesOnly bool) ([]certificateutil.CertificateInfoModel, []profileutil.ProvisioningProfileInfoModel, error) {
+func getFilesToExport(archivePath string, installedCertificates []certificateutil.CertificateInfoModel, installedInstallerCertificates []certificateutil.CertificateInfoModel, installedProfiles []profileutil.ProvisioningProfileInfoModel, certificatesOnly bool, exportMethods []string, selectPolicy codesign.SelectPolicy, teamID string) ([]certificateutil.CertificateInfoModel, []profileutil.ProvisioningProfileInfoModel, []codesign.ExportOptions, error) {
 	macOS, err := xcarchive.IsMacOS(archivePath)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	var certificate certificateutil.CertificateInfoModel
@@ -83,48 +87,64 @@ func getFilesToExport(archivePath string, installedCertificates []certificateuti
 	if macOS {
 		archive, archiveCodeSignGroup, err = getMacOSCodeSignGroup(archivePath, installedCertificates)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 		certificate = archiveCodeSignGroup.Certificate()
 	} else {
 		archive, archiveCodeSignGroup, err = getIOSCodeSignGroup(archivePath, installedCertificates)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 		certificate = archiveCodeSignGroup.Certificate()
 	}
 
+	// The archive's code signing files are looked up from every installed file, the export ones only from the requested team's.
+	if teamID != "" {
+		if certificate.TeamID != teamID {
+			fmt.Println()
+			log.Warnf("🚨  The archive was signed by team %s (%s), which differs from the requested team (%s).", certificate.TeamName, certificate.TeamID, teamID)
+			log.Warnf("The code signing files used for the archive are collected anyway, the export code signing files are collected for team %s only.", teamID)
+		}
+
+		installedCertificates = codesign.FilterCertificatesByTeam(installedCertificates, teamID)
+		installedInstallerCertificates = codesign.FilterCertificatesByTeam(installedInstallerCertificates, teamID)
+		installedProfiles = codesign.FilterProfilesByTeam(installedProfiles, teamID)
+
+		log.Debugf("Code signing files of team %s: %d certificates, %d installer certificates, %d profiles", teamID, len(installedCertificates), len(installedInstallerCertificates), len(installedProfiles))
+	}
+
 	var certificatesToExport []certificateutil.CertificateInfoModel
 	var profilesToExport []profileutil.ProvisioningProfileInfoModel
+	var exportOptions []codesign.ExportOptions
 
 	if certificatesOnly {
 		exportCertificate, err := collectExportCertificate(macOS, certificate, installedCertificates, installedInstallerCertificates)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 
 		certificatesToExport = append(certificatesToExport, certificate)
 		certificatesToExport = append(certificatesToExport, exportCertificate...)
 	} else {
-		certificatesToExport, profilesToExport, err = collectCertificatesAndProfiles(archive, installedCertificates, installedProfiles, certificatesToExport, profilesToExport, archiveCodeSignGroup)
+		certificatesToExport, profilesToExport, exportOptions, err = collectCertificatesAndProfiles(archive, installedCertificates, installedProfiles, certificatesToExport, profilesToExport, archiveCodeSignGroup, exportMethods, selectPolicy)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 	}
 
-	return certificatesToExport, profilesToExport, nil
+	return certificatesToExport, profilesToExport, exportOptions, nil
 }
 
 func collectCertificatesAndProfiles(archive Archive,
 	installedCertificates []certificateutil.CertificateInfoModel, installedProfiles []profileutil.ProvisioningProfileInfoModel,
 	certificatesToExport []certificateutil.CertificateInfoModel, profilesToExport []profileutil.ProvisioningProfileInfoModel,
-	archiveCodeSignGroup export.CodeSignGroup) ([]certificateutil.CertificateInfoModel, []profileutil.ProvisioningProfileInfoModel, error) {
+	archiveCodeSignGroup export.CodeSignGroup, exportMethods []string, selectPolicy codesign.SelectPolicy) ([]certificateutil.CertificateInfoModel, []profileutil.ProvisioningProfileInfoModel, []codesign.ExportOptions, error) {
 
 	_, macOS := archive.(xcarchive.MacosArchive)
 
-	groups, err := collectExportCodeSignGroups(archive, installedCertificates, installedProfiles)
+	groups, exportOptions, err := collectExportCodeSignGroups(archive, installedCertificates, installedProfiles, exportMethods, selectPolicy)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	var exportCodeSignGroups []export.CodeSignGroup
@@ -143,7 +163,7 @@ func collectCertificatesAndProfiles(archive Archive,
 	}
 
 	if len(exportCodeSignGroups) == 0 {
-		return nil, nil, errors.New("no export code sign groups collected")
+		return nil, nil, nil, errors.New("no export code sign groups collected")
 	}
 
 	codeSignGroups := append(exportCodeSignGroups, archiveCodeSignGroup)
@@ -151,5 +171,5 @@ func collectCertificatesAndProfiles(archive Archive,
 	certificatesToExport = append(certificatesToExport, certificates...)
 	profilesToExport = append(profilesToExport, profiles...)
 
-	return certificatesToExport, profilesToExport, nil
+	return certificatesToExport, profilesToExport, exportOptions, nil
 }
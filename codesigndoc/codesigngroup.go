@@ -9,6 +9,7 @@ import (
 	"github.com/bitrise-io/codesigndoc/codesign"
 	"github.com/bitrise-io/go-utils/colorstring"
 	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-io/go-utils/sliceutil"
 	"github.com/bitrise-io/go-xcode/certificateutil"
 	"github.com/bitrise-io/go-xcode/export"
 	"github.com/bitrise-io/go-xcode/exportoptions"
@@ -46,16 +47,8 @@ func extractCertificatesAndProfiles(codeSignGroups ...export.CodeSignGroup) ([]c
 	return certificates, profiles
 }
 
-// exportMethod returns which ipa/pkg/app export type is allowed by the given codesign group.
-func exportMethod(group export.CodeSignGroup) string {
-	for _, profile := range group.BundleIDProfileMap() {
-		return string(profile.ExportType)
-	}
-	return ""
-}
-
-// printCodesignGroup prints the given codesign group.
-func printCodesignGroup(group export.CodeSignGroup) {
+// printCodesignGroup prints the given codesign group, labelling the bundle IDs with their targets' name and kind.
+func printCodesignGroup(group export.CodeSignGroup, targets map[string]targetInfo) {
 	fmt.Printf("%s %s (%s)\n", colorstring.Green("development team:"), group.Certificate().TeamName, group.Certificate().TeamID)
 	fmt.Printf("%s %s [%s]\n", colorstring.Green("codesign identity:"), group.Certificate().CommonName, group.Certificate().Serial)
 
@@ -63,13 +56,19 @@ func printCodesignGroup(group export.CodeSignGroup) {
 		fmt.Printf("%s %s [%s]\n", colorstring.Green("installer codesign identity:"), group.InstallerCertificate().CommonName, group.InstallerCertificate().Serial)
 	}
 
-	idx := -1
-	for bundleID, profile := range group.BundleIDProfileMap() {
-		idx++
+	bundleIDProfileMap := group.BundleIDProfileMap()
+	var bundleIDs []string
+	for bundleID := range bundleIDProfileMap {
+		bundleIDs = append(bundleIDs, bundleID)
+	}
+	sort.Strings(bundleIDs)
+
+	for idx, bundleID := range bundleIDs {
+		profile := bundleIDProfileMap[bundleID]
 		if idx == 0 {
-			fmt.Printf("%s %s -> %s\n", colorstring.Greenf("provisioning profiles:"), profile.Name, bundleID)
+			fmt.Printf("%s %s -> %s\n", colorstring.Greenf("provisioning profiles:"), profile.Name, targetLabel(bundleID, targets))
 		} else {
-			fmt.Printf("%s%s -> %s\n", strings.Repeat(" ", len("provisioning profiles: ")), profile.Name, bundleID)
+			fmt.Printf("%s%s -> %s\n", strings.Repeat(" ", len("provisioning profiles: ")), profile.Name, targetLabel(bundleID, targets))
 		}
 	}
 }
@@ -79,13 +78,7 @@ func collectExportCertificate(isMacArchive bool, archiveCertificate certificateu
 	var selectedCertificates []certificateutil.CertificateInfoModel
 
 	// Export method
-	exportMethods := []string{"development", "app-store"}
-
-	if isMacArchive {
-		exportMethods = append(exportMethods, "developer-id")
-	} else {
-		exportMethods = append(exportMethods, "ad-hoc", "enterprise")
-	}
+	exportMethods := codesign.ExportMethods(isMacArchive)
 
 	// Asking the user over and over until we find a valid certificate for the selected export method.
 	for searchingValidCertificate := true; searchingValidCertificate; {
@@ -119,14 +112,14 @@ func filterCertificates(isMacArchive bool, selectedExportMethod, selectedTeam st
 	log.Debugf("InstalledCerts: %v\n", installedCertificates)
 
 	// Filter the installed certificates by distribution type
-	switch selectedExportMethod {
-	case "development":
+	switch {
+	case codesign.IsDevelopmentExportMethod(selectedExportMethod):
 		certsForSelectedExport = certificateutil.FilterCertificateInfoModelsByFilterFunc(installedCertificates, func(certInfo certificateutil.CertificateInfoModel) bool {
 			return !codesign.IsDistributionCertificate(certInfo)
 		})
 
 		log.Debugf("DeveloperDistribution certificates: %v\n", certsForSelectedExport)
-	case "installer":
+	case selectedExportMethod == "installer":
 		certsForSelectedExport = certificateutil.FilterCertificateInfoModelsByFilterFunc(installedInstallerCertificates, func(certInfo certificateutil.CertificateInfoModel) bool {
 			return codesign.IsInstallerCertificate(certInfo)
 		})
@@ -207,10 +200,10 @@ Would you like to use this team to export an ipa file?`, archiveCertificate.Team
 	}
 
 	certType := "distribution"
-	switch selectedExportMethod {
-	case "development":
+	switch {
+	case codesign.IsDevelopmentExportMethod(selectedExportMethod):
 		certType = "development"
-	case "installer":
+	case selectedExportMethod == "installer":
 		certType = "installer"
 	}
 
@@ -228,10 +221,10 @@ Would you like to use this team to export an ipa file?`, archiveCertificate.Team
 		}
 	}
 
-	// Collect installer cert for macOS app-store export.
-	if selectedExportMethod == "app-store" && isMacArchive {
+	// Collect installer cert for macOS app-store and developer-id export.
+	if isMacArchive && (codesign.IsAppStoreExportMethod(selectedExportMethod) || selectedExportMethod == "developer-id") {
 		fmt.Println()
-		question := `Do you want to collect installer certificate for the app-store export? [yes,no]`
+		question := fmt.Sprintf(`Do you want to collect installer certificate for the %s export? [yes,no]`, selectedExportMethod)
 		collectInstallerCert, err := goinp.AskForBoolWithDefault(question, true)
 		if err != nil {
 			return nil, fmt.Errorf("failed to read input: %s", err)
@@ -239,7 +232,7 @@ Would you like to use this team to export an ipa file?`, archiveCertificate.Team
 
 		filteredInstallerCertificatesByTeam := codesign.MapCertificatesByTeam(installedInstallerCertificates)
 		if !hasCertificateForDistType("installer", filteredInstallerCertificatesByTeam[selectedTeam]) {
-			log.Warnf("🚨   The selected team (%s) doesn't have installer certificate for MacOS app-store export", selectedTeam)
+			log.Warnf("🚨   The selected team (%s) doesn't have installer certificate for MacOS %s export", selectedTeam, selectedExportMethod)
 			return selectedCertificates, nil
 		}
 
@@ -254,212 +247,314 @@ Would you like to use this team to export an ipa file?`, archiveCertificate.Team
 	return selectedCertificates, nil
 }
 
-// collectExportCodeSignGroups returns the codesign groups required to export an ipa/.app with the selected export methods.
-func collectExportCodeSignGroups(archive Archive, installedCertificates []certificateutil.CertificateInfoModel, installedProfiles []profileutil.ProvisioningProfileInfoModel) ([]export.CodeSignGroup, error) {
+// collectExportCodeSignGroups returns the codesign groups required to export an ipa/.app with the selected export methods,
+// and the export options to export the archive with each of the codesign groups.
+// If exportMethods is empty the export methods are asked interactively,
+// otherwise a codesign group is collected for each of the given export methods without asking.
+// The selectPolicy is used to choose from multiple qualifying certificates or profiles, if set.
+func collectExportCodeSignGroups(archive Archive, installedCertificates []certificateutil.CertificateInfoModel, installedProfiles []profileutil.ProvisioningProfileInfoModel, exportMethods []string, selectPolicy codesign.SelectPolicy) ([]export.CodeSignGroup, []codesign.ExportOptions, error) {
 	var collectedCodeSignGroups []export.CodeSignGroup
+	var exportOptions []codesign.ExportOptions
 	_, isMacArchive := archive.(xcarchive.MacosArchive)
+	targets := archiveTargets(archive)
 
 	codeSignGroups := collectExportSelectableCodeSignGroups(archive, installedCertificates, installedProfiles)
 	if len(codeSignGroups) == 0 {
-		return nil, errors.New("no code sign files (Codesign Identities and Provisioning Profiles) are installed to export an ipa\n" + collectCodesigningFilesInfo)
+		printUncoveredTargets(uncoveredBundleIDs(archive, installedCertificates, installedProfiles), targets)
+		return nil, nil, errors.New("no code sign files (Codesign Identities and Provisioning Profiles) are installed to export an ipa\n" + collectCodesigningFilesInfo)
 	}
 
-	exportMethods := []string{"development", "app-store"}
+	if len(exportMethods) > 0 {
+		availableExportMethods := codesign.ExportMethods(isMacArchive)
+		for _, exportMethod := range exportMethods {
+			if !sliceutil.IsStringInSlice(exportMethod, availableExportMethods) {
+				return nil, nil, fmt.Errorf("invalid export method (%s) for the archive, available export methods: %s", exportMethod, strings.Join(availableExportMethods, ", "))
+			}
+		}
 
-	if isMacArchive {
-		exportMethods = append(exportMethods, "developer-id")
-	} else {
-		exportMethods = append(exportMethods, "ad-hoc", "enterprise")
+		for _, exportMethod := range exportMethods {
+			fmt.Println()
+			log.Infof("Collecting code sign files for %s export", exportMethod)
+
+			collectedCodeSignGroup, err := collectExportCodeSignGroup(isMacArchive, exportMethod, codeSignGroups, targets, selectPolicy, false)
+			if err != nil {
+				return nil, nil, err
+			}
+			if collectedCodeSignGroup == nil {
+				printUncoveredTargets(uncoveredExportMethodBundleIDs(archive, installedCertificates, installedProfiles, exportMethod), targets)
+				return nil, nil, fmt.Errorf("no code sign files (Codesign Identities and Provisioning Profiles) are installed for the %s export method\n%s", exportMethod, collectCodesigningFilesInfo)
+			}
+
+			collectedCodeSignGroups = append(collectedCodeSignGroups, collectedCodeSignGroup)
+			exportOptions = append(exportOptions, codesign.NewExportOptions(exportMethod, collectedCodeSignGroup))
+		}
+
+		return collectedCodeSignGroups, exportOptions, nil
 	}
 
 	for {
-		selectedExportMethod, err := goinp.SelectFromStringsWithDefault("Select the ipa export method", 1, exportMethods)
+		selectedExportMethod, err := goinp.SelectFromStringsWithDefault("Select the ipa export method", 1, codesign.ExportMethods(isMacArchive))
 		if err != nil {
-			return nil, fmt.Errorf("failed to read input: %s", err)
+			return nil, nil, fmt.Errorf("failed to read input: %s", err)
 		}
 		log.Debugf("selected export method: %v", selectedExportMethod)
 
-		fmt.Println()
-		filteredCodeSignGroups := export.FilterSelectableCodeSignGroups(codeSignGroups,
-			export.CreateExportMethodSelectableCodeSignGroupFilter(exportoptions.Method(selectedExportMethod)),
-		)
-
-		log.Debugf("\n")
-		log.Debugf("Filtered Codesign Groups:")
-		for _, group := range codeSignGroups {
-			log.Debugf(group.String())
+		collectedCodeSignGroup, err := collectExportCodeSignGroup(isMacArchive, selectedExportMethod, codeSignGroups, targets, selectPolicy, true)
+		if err != nil {
+			return nil, nil, err
 		}
 
-		if len(filteredCodeSignGroups) == 0 {
+		if collectedCodeSignGroup == nil {
+			printUncoveredTargets(uncoveredExportMethodBundleIDs(archive, installedCertificates, installedProfiles, selectedExportMethod), targets)
 			fmt.Println()
 			log.Errorf(collectCodesigningFilesInfo)
-			fmt.Println()
-			question := "Do you want to collect another ipa export code sign files"
-			question += "\n(select NO to finish collecting codesign files and continue)"
-			anotherExport, err := goinp.AskForBoolWithDefault(question, false)
-			if err != nil {
-				return nil, fmt.Errorf("failed to read input: %s", err)
-			}
-			if !anotherExport {
-				break
-			}
-			continue
+		} else {
+			collectedCodeSignGroups = append(collectedCodeSignGroups, collectedCodeSignGroup)
+			exportOptions = append(exportOptions, codesign.NewExportOptions(selectedExportMethod, collectedCodeSignGroup))
 		}
 
-		// Select certificate
-		var certificates []certificateutil.CertificateInfoModel
-		var certificateOptions []string
-		for _, group := range filteredCodeSignGroups {
-			certificate := group.Certificate
-			certificates = append(certificates, certificate)
-			certificateOption := fmt.Sprintf("%s [%s] - development team: %s", certificate.CommonName, certificate.Serial, certificate.TeamName)
-			certificateOptions = append(certificateOptions, certificateOption)
+		fmt.Println()
+		question := "Do you want to collect another ipa export code sign files"
+		question += "\n(select NO to finish collecting codesign files and continue)"
+		anotherExport, err := goinp.AskForBoolWithDefault(question, false)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read input: %s", err)
+		}
+		if !anotherExport {
+			break
 		}
+	}
 
-		var selectedCertificateOption string
-		if len(certificateOptions) == 1 {
-			selectedCertificateOption = certificateOptions[0]
+	return collectedCodeSignGroups, exportOptions, nil
+}
 
-			fmt.Printf("Codesign Identity for %s ipa export: %s\n", selectedExportMethod, selectedCertificateOption)
-		} else {
-			sort.Strings(certificateOptions)
+// collectExportCodeSignGroup returns the codesign group to export an ipa/.app with the given export method,
+// or nil if none of the selectable codesign groups can be used for the export method.
+// The certificate and the profiles are chosen by the selectPolicy if set, even if only one of them qualifies.
+// Otherwise, if interactive is false, the only certificate and latest profile candidates are selected and
+// an error is returned if there are multiple candidates to choose from.
+// The targets are used to label the bundle IDs with their targets' name and kind.
+func collectExportCodeSignGroup(isMacArchive bool, exportMethod string, codeSignGroups []export.SelectableCodeSignGroup, targets map[string]targetInfo, selectPolicy codesign.SelectPolicy, interactive bool) (export.CodeSignGroup, error) {
+	profileExportType, err := codesign.ProfileExportType(exportMethod)
+	if err != nil {
+		return nil, err
+	}
 
-			question := fmt.Sprintf("Select the Codesign Identity for %s ipa export", selectedExportMethod)
-			selectedCertificateOption, err = goinp.SelectFromStringsWithDefault(question, 1, certificateOptions)
-			if err != nil {
-				return nil, fmt.Errorf("failed to read input: %s", err)
-			}
+	fmt.Println()
+	filteredCodeSignGroups := export.FilterSelectableCodeSignGroups(codeSignGroups,
+		export.CreateExportMethodSelectableCodeSignGroupFilter(profileExportType),
+	)
+
+	log.Debugf("\n")
+	log.Debugf("Filtered Codesign Groups:")
+	for _, group := range filteredCodeSignGroups {
+		log.Debugf(group.String())
+	}
+
+	if len(filteredCodeSignGroups) == 0 {
+		return nil, nil
+	}
+
+	// Select certificate
+	var certificates []certificateutil.CertificateInfoModel
+	var certificateOptions []string
+	for _, group := range filteredCodeSignGroups {
+		certificate := group.Certificate
+		certificates = append(certificates, certificate)
+		certificateOption := fmt.Sprintf("%s [%s] - development team: %s", certificate.CommonName, certificate.Serial, certificate.TeamName)
+		certificateOptions = append(certificateOptions, certificateOption)
+	}
+
+	// The select policy is applied to a single candidate too, so a team policy never selects another team's certificate.
+	var selectedCertificateOption string
+	if selectPolicy.IsSet() {
+		certificate, err := selectPolicy.SelectCertificate(certificates)
+		if err != nil {
+			return nil, err
 		}
+		selectedCertificateOption = fmt.Sprintf("%s [%s] - development team: %s", certificate.CommonName, certificate.Serial, certificate.TeamName)
+	} else if len(certificateOptions) == 1 {
+		selectedCertificateOption = certificateOptions[0]
 
-		var selectedCertificate *certificateutil.CertificateInfoModel
-		for _, certificate := range certificates {
-			option := fmt.Sprintf("%s [%s] - development team: %s", certificate.CommonName, certificate.Serial, certificate.TeamName)
-			if option == selectedCertificateOption {
-				selectedCertificate = &certificate
-				break
-			}
+		fmt.Printf("Codesign Identity for %s ipa export: %s\n", exportMethod, selectedCertificateOption)
+	} else {
+		sort.Strings(certificateOptions)
+
+		question := fmt.Sprintf("Select the Codesign Identity for %s ipa export", exportMethod)
+		selectedCertificateOption, err = selectOption(question, certificateOptions, interactive)
+		if err != nil {
+			return nil, err
 		}
-		if selectedCertificate == nil {
-			return nil, errors.New("failed to find selected Codesign Identity")
+	}
+
+	var selectedCertificate *certificateutil.CertificateInfoModel
+	for _, certificate := range certificates {
+		option := fmt.Sprintf("%s [%s] - development team: %s", certificate.CommonName, certificate.Serial, certificate.TeamName)
+		if option == selectedCertificateOption {
+			selectedCertificate = &certificate
+			break
 		}
+	}
+	if selectedCertificate == nil {
+		return nil, errors.New("failed to find selected Codesign Identity")
+	}
 
-		// Select Profiles
-		bundleIDProfilesMap := map[string][]profileutil.ProvisioningProfileInfoModel{}
-		for _, group := range filteredCodeSignGroups {
-			option := fmt.Sprintf("%s [%s] - development team: %s", group.Certificate.CommonName, group.Certificate.Serial, group.Certificate.TeamName)
-			if option == selectedCertificateOption {
-				bundleIDProfilesMap = group.BundleIDProfilesMap
-				break
-			}
+	// Select Profiles
+	bundleIDProfilesMap := map[string][]profileutil.ProvisioningProfileInfoModel{}
+	for _, group := range filteredCodeSignGroups {
+		option := fmt.Sprintf("%s [%s] - development team: %s", group.Certificate.CommonName, group.Certificate.Serial, group.Certificate.TeamName)
+		if option == selectedCertificateOption {
+			bundleIDProfilesMap = group.BundleIDProfilesMap
+			break
 		}
-		if len(bundleIDProfilesMap) == 0 {
-			return nil, errors.New("failed to find Provisioning Profiles for Code Sign Identity")
+	}
+	if len(bundleIDProfilesMap) == 0 {
+		return nil, errors.New("failed to find Provisioning Profiles for Code Sign Identity")
+	}
+
+	selectedBundleIDProfileMap := map[string]profileutil.ProvisioningProfileInfoModel{}
+	for bundleID, profiles := range bundleIDProfilesMap {
+		profiles = codesign.FilterLatestProfiles(profiles)
+		var profileOptions []string
+		for _, profile := range profiles {
+			profileOption := fmt.Sprintf("%s (%s)", profile.Name, profile.UUID)
+			profileOptions = append(profileOptions, profileOption)
 		}
 
-		selectedBundleIDProfileMap := map[string]profileutil.ProvisioningProfileInfoModel{}
-		for bundleID, profiles := range bundleIDProfilesMap {
-			profiles = codesign.FilterLatestProfiles(profiles)
-			var profileOptions []string
-			for _, profile := range profiles {
-				profileOption := fmt.Sprintf("%s (%s)", profile.Name, profile.UUID)
-				profileOptions = append(profileOptions, profileOption)
+		var selectedProfileOption string
+		if selectPolicy.IsSet() {
+			fmt.Println()
+			log.Printf("Selecting the Provisioning Profile to sign target: %s", targetLabel(bundleID, targets))
+			profile, err := selectPolicy.SelectProfile(profiles)
+			if err != nil {
+				return nil, err
 			}
+			selectedProfileOption = fmt.Sprintf("%s (%s)", profile.Name, profile.UUID)
+		} else if len(profileOptions) == 1 {
+			selectedProfileOption = profileOptions[0]
 
-			var selectedProfileOption string
-			if len(profileOptions) == 1 {
-				selectedProfileOption = profileOptions[0]
-
-				fmt.Printf("Provisioning Profile to sign target (%s): %s\n", bundleID, selectedProfileOption)
-			} else {
-				sort.Strings(profileOptions)
+			fmt.Printf("Provisioning Profile to sign target %s: %s\n", targetLabel(bundleID, targets), selectedProfileOption)
+		} else {
+			sort.Strings(profileOptions)
 
-				fmt.Println()
-				question := fmt.Sprintf("Select the Provisioning Profile to sign target with bundle ID: %s", bundleID)
-				selectedProfileOption, err = goinp.SelectFromStringsWithDefault(question, 1, profileOptions)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read input: %s", err)
-				}
+			fmt.Println()
+			question := fmt.Sprintf("Select the Provisioning Profile to sign target: %s", targetLabel(bundleID, targets))
+			selectedProfileOption, err = selectOption(question, profileOptions, interactive)
+			if err != nil {
+				return nil, err
 			}
+		}
 
-			for _, profile := range profiles {
-				option := fmt.Sprintf("%s (%s)", profile.Name, profile.UUID)
-				if option == selectedProfileOption {
-					selectedBundleIDProfileMap[bundleID] = profile
-				}
+		for _, profile := range profiles {
+			option := fmt.Sprintf("%s (%s)", profile.Name, profile.UUID)
+			if option == selectedProfileOption {
+				selectedBundleIDProfileMap[bundleID] = profile
 			}
 		}
-		if len(selectedBundleIDProfileMap) != len(bundleIDProfilesMap) {
-			return nil, fmt.Errorf("failed to find Provisioning Profiles for ipa export")
-		}
+	}
+	if len(selectedBundleIDProfileMap) != len(bundleIDProfilesMap) {
+		return nil, fmt.Errorf("failed to find Provisioning Profiles for ipa export")
+	}
 
-		var collectedCodeSignGroup export.CodeSignGroup
-		if isMacArchive {
-			var installedInstallerCertificates []certificateutil.CertificateInfoModel
+	var collectedCodeSignGroup export.CodeSignGroup
+	if isMacArchive {
+		var installedInstallerCertificates []certificateutil.CertificateInfoModel
 
-			var selectedInstallerCertificate certificateutil.CertificateInfoModel
-			if selectedExportMethod == string(exportoptions.MethodAppStore) {
-				installedInstallerCertificates, err = certificateutil.InstalledInstallerCertificateInfos()
-				if err != nil {
-					log.Errorf("Failed to read installed Installer certificates, error: %s", err)
-				}
+		var selectedInstallerCertificate certificateutil.CertificateInfoModel
+		if codesign.IsAppStoreExportMethod(exportMethod) || exportMethod == string(exportoptions.MethodDeveloperID) {
+			installedInstallerCertificates, err = certificateutil.InstalledInstallerCertificateInfos()
+			if err != nil {
+				log.Errorf("Failed to read installed Installer certificates, error: %s", err)
+			}
 
-				installedInstallerCertificates = certificateutil.FilterValidCertificateInfos(installedInstallerCertificates).ValidCertificates
+			installedInstallerCertificates = certificateutil.FilterValidCertificateInfos(installedInstallerCertificates).ValidCertificates
 
-				log.Debugf("\n")
-				log.Debugf("Installed installer certificates:")
-				for _, certInfo := range installedInstallerCertificates {
-					log.Debugf(certInfo.String())
-				}
+			log.Debugf("\n")
+			log.Debugf("Installed installer certificates:")
+			for _, certInfo := range installedInstallerCertificates {
+				log.Debugf(certInfo.String())
+			}
 
-				for _, installerCertificate := range installedInstallerCertificates {
-					if installerCertificate.TeamID == selectedCertificate.TeamID {
-						selectedInstallerCertificate = installerCertificate
-						break
-					}
-				}
+			// Developer ID exports are signed with Developer ID Installer, App Store exports with Mac Installer Distribution certificates.
+			isInstallerForExportMethod := codesign.IsMacAppStoreInstallerCertificate
+			if exportMethod == string(exportoptions.MethodDeveloperID) {
+				isInstallerForExportMethod = codesign.IsDeveloperIDInstallerCertificate
 			}
 
-			collectedCodeSignGroup = export.NewMacGroup(*selectedCertificate, &selectedInstallerCertificate, selectedBundleIDProfileMap)
-		} else {
-			collectedCodeSignGroup = export.NewIOSGroup(*selectedCertificate, selectedBundleIDProfileMap)
+			for _, installerCertificate := range installedInstallerCertificates {
+				if installerCertificate.TeamID == selectedCertificate.TeamID && isInstallerForExportMethod(installerCertificate) {
+					selectedInstallerCertificate = installerCertificate
+					break
+				}
+			}
 		}
 
-		fmt.Println()
-		log.Infof("Codesign settings will be used for %s .ipa/.app export:", exportMethod(collectedCodeSignGroup))
-		printCodesignGroup(collectedCodeSignGroup)
+		collectedCodeSignGroup = export.NewMacGroup(*selectedCertificate, &selectedInstallerCertificate, selectedBundleIDProfileMap)
+	} else {
+		collectedCodeSignGroup = export.NewIOSGroup(*selectedCertificate, selectedBundleIDProfileMap)
+	}
 
-		collectedCodeSignGroups = append(collectedCodeSignGroups, collectedCodeSignGroup)
+	fmt.Println()
+	log.Infof("Codesign settings will be used for %s .ipa/.app export:", exportMethod)
+	printCodesignGroup(collectedCodeSignGroup, targets)
 
-		fmt.Println()
-		question := "Do you want to collect another ipa export code sign files"
-		question += "\n(select NO to finish collecting codesign files and continue)"
-		anotherExport, err := goinp.AskForBoolWithDefault(question, false)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read input: %s", err)
-		}
-		if !anotherExport {
-			break
-		}
+	return collectedCodeSignGroup, nil
+}
+
+// uncoveredExportMethodBundleIDs returns the bundle IDs of the archive without a matching provisioning profile for the export method.
+func uncoveredExportMethodBundleIDs(archive Archive, installedCertificates []certificateutil.CertificateInfoModel, installedProfiles []profileutil.ProvisioningProfileInfoModel, exportMethod string) []string {
+	profileExportType, err := codesign.ProfileExportType(exportMethod)
+	if err != nil {
+		return nil
+	}
+	return uncoveredBundleIDs(archive, installedCertificates, installedProfiles, export.CreateExportMethodSelectableCodeSignGroupFilter(profileExportType))
+}
+
+// printUncoveredTargets prints the targets without a matching provisioning profile.
+func printUncoveredTargets(bundleIDs []string, targets map[string]targetInfo) {
+	if len(bundleIDs) == 0 {
+		return
 	}
 
-	return collectedCodeSignGroups, nil
+	fmt.Println()
+	log.Warnf("No matching installed Provisioning Profile found for the targets:")
+	for _, bundleID := range bundleIDs {
+		log.Warnf("- %s", targetLabel(bundleID, targets))
+	}
+}
+
+// selectOption asks the user to select one of the given options.
+// If interactive is false, an error listing the options is returned instead of asking.
+func selectOption(question string, options []string, interactive bool) (string, error) {
+	if !interactive {
+		return "", fmt.Errorf("%s: multiple candidates found, can't decide automatically:\n- %s", question, strings.Join(options, "\n- "))
+	}
+
+	selectedOption, err := goinp.SelectFromStringsWithDefault(question, 1, options)
+	if err != nil {
+		return "", fmt.Errorf("failed to read input: %s", err)
+	}
+	return selectedOption, nil
 }
 
 // collectExportSelectableCodeSignGroups returns every possible codesign group which can be used to export an ipa file.
 func collectExportSelectableCodeSignGroups(archive Archive, installedCertificates []certificateutil.CertificateInfoModel, installedProfiles []profileutil.ProvisioningProfileInfoModel) []export.SelectableCodeSignGroup {
 	bundleIDEEntitlementsMap := archive.BundleIDEntitlementsMap()
+	targets := archiveTargets(archive)
+
+	var bundleIDs []string
+	for bundleID := range bundleIDEEntitlementsMap {
+		bundleIDs = append(bundleIDs, bundleID)
+	}
+	sort.Strings(bundleIDs)
 
 	fmt.Println()
 	log.Infof("Targets to sign:")
-	for bundleID, entitlements := range bundleIDEEntitlementsMap {
-		fmt.Printf("- %s with %d capabilities\n", bundleID, len(entitlements))
+	for _, bundleID := range bundleIDs {
+		fmt.Printf("- %s with %d capabilities\n", targetLabel(bundleID, targets), len(bundleIDEEntitlementsMap[bundleID]))
 	}
 	fmt.Println()
 
-	var bundleIDs []string
-	for bundleID := range bundleIDEEntitlementsMap {
-		bundleIDs = append(bundleIDs, bundleID)
-	}
 	codeSignGroups := export.CreateSelectableCodeSignGroups(installedCertificates, installedProfiles, bundleIDs)
 
 	log.Debugf("Codesign Groups:")
@@ -499,13 +594,13 @@ func collectExportSelectableCodeSignGroups(archive Archive, installedCertificate
 // If isDistCert == true it will search for Distribution Certificates. If it's == false it will search for Development Certificates.
 // If the team doesn't have any certificate for the selected cert type, it will return false.
 func hasCertificateForDistType(exportMethod string, certificates []certificateutil.CertificateInfoModel) bool {
-	switch exportMethod {
-	case "development":
+	switch {
+	case codesign.IsDevelopmentExportMethod(exportMethod):
 		developmentCertificates := certificateutil.FilterCertificateInfoModelsByFilterFunc(certificates, func(certInfo certificateutil.CertificateInfoModel) bool {
 			return !codesign.IsDistributionCertificate(certInfo)
 		})
 		return len(developmentCertificates) > 0
-	case "installer":
+	case exportMethod == "installer":
 		installerCertificates := certificateutil.FilterCertificateInfoModelsByFilterFunc(certificates, func(certInfo certificateutil.CertificateInfoModel) bool {
 			return codesign.IsInstallerCertificate(certInfo)
 		})
@@ -582,7 +677,7 @@ func getCodeSignGroup(archive Archive, installedCertificates []certificateutil.C
 
 	fmt.Println()
 	log.Infof("Codesign settings used for archive:")
-	printCodesignGroup(archiveCodeSignGroup)
+	printCodesignGroup(archiveCodeSignGroup, archiveTargets(archive))
 
 	return archiveCodeSignGroup, nil
 }
@@ -0,0 +1,106 @@
+package codesigndoc
+
+import (
+	"fmt"
+
+	"github.com/bitrise-io/codesigndoc/utility"
+	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-io/go-xcode/plistutil"
+	"github.com/bitrise-io/go-xcode/profileutil"
+	"github.com/bitrise-io/go-xcode/xcodeproject/serialized"
+	"github.com/bitrise-io/go-xcode/xcodeproject/xcodeproj"
+)
+
+// ProjectArchive describes the code signing requirements of an Xcode project's archivable targets,
+// read from the project's build settings without archiving the project.
+type ProjectArchive struct {
+	bundleIDEntitlementsMap map[string]plistutil.PlistData
+	targets                 map[string]targetInfo
+	isXcodeManaged          bool
+	signingIdentity         string
+}
+
+// NewProjectArchive reads the bundle IDs and entitlements of the scheme's archivable target
+// and the targets it embeds. It also returns whether the target is a macOS target.
+func NewProjectArchive(projectPath, schemeName string) (ProjectArchive, bool, error) {
+	project, scheme, configuration, err := utility.OpenArchivableProject(projectPath, schemeName, "")
+	if err != nil {
+		return ProjectArchive{}, false, err
+	}
+
+	archiveEntry, ok := scheme.AppBuildActionEntry()
+	if !ok {
+		return ProjectArchive{}, false, fmt.Errorf("archivable entry not found in project: %s, scheme: %s", project.Path, scheme.Name)
+	}
+
+	mainTarget, ok := project.Proj.Target(archiveEntry.BuildableReference.BlueprintIdentifier)
+	if !ok {
+		return ProjectArchive{}, false, fmt.Errorf("target not found: %s", archiveEntry.BuildableReference.BlueprintIdentifier)
+	}
+
+	mainTargetSettings, err := project.TargetBuildSettings(mainTarget.Name, configuration)
+	if err != nil {
+		return ProjectArchive{}, false, fmt.Errorf("failed to get target (%s) build settings: %s", mainTarget.Name, err)
+	}
+
+	platform, err := utility.BuildableTargetPlatform(project, scheme, configuration, utility.XcodeBuild{})
+	if err != nil {
+		return ProjectArchive{}, false, err
+	}
+
+	codeSignStyle, _ := mainTargetSettings.String("CODE_SIGN_STYLE")
+	signingIdentity, _ := mainTargetSettings.String("CODE_SIGN_IDENTITY")
+
+	archive := ProjectArchive{
+		bundleIDEntitlementsMap: map[string]plistutil.PlistData{},
+		targets:                 map[string]targetInfo{},
+		isXcodeManaged:          codeSignStyle == "Automatic",
+		signingIdentity:         signingIdentity,
+	}
+
+	targets := append([]xcodeproj.Target{mainTarget}, mainTarget.DependentExecutableProductTargets()...)
+	for _, target := range targets {
+		bundleID, err := project.TargetBundleID(target.Name, configuration)
+		if err != nil {
+			return ProjectArchive{}, false, fmt.Errorf("failed to get target (%s) bundle ID: %s", target.Name, err)
+		}
+
+		entitlements, err := project.TargetCodeSignEntitlements(target.Name, configuration)
+		if err != nil && !serialized.IsKeyNotFoundError(err) {
+			return ProjectArchive{}, false, fmt.Errorf("failed to get target (%s) entitlements: %s", target.Name, err)
+		}
+
+		kind := productTypeKind(target.ProductType)
+		if target.IsAppExtensionProduct() {
+			if infoPlist, _, err := project.ReadTargetInfoplist(target.Name, configuration); err == nil {
+				kind = extensionKind(plistutil.PlistData(infoPlist), false)
+			}
+		}
+
+		log.Debugf("Target: %s (%s), bundle ID: %s, %d capabilities", target.Name, kind, bundleID, len(entitlements))
+		archive.bundleIDEntitlementsMap[bundleID] = plistutil.PlistData(entitlements)
+		archive.targets[bundleID] = targetInfo{Name: target.Name, Kind: kind}
+	}
+
+	return archive, platform == utility.OSX, nil
+}
+
+// BundleIDEntitlementsMap ...
+func (archive ProjectArchive) BundleIDEntitlementsMap() map[string]plistutil.PlistData {
+	return archive.bundleIDEntitlementsMap
+}
+
+// IsXcodeManaged ...
+func (archive ProjectArchive) IsXcodeManaged() bool {
+	return archive.isXcodeManaged
+}
+
+// SigningIdentity ...
+func (archive ProjectArchive) SigningIdentity() string {
+	return archive.signingIdentity
+}
+
+// BundleIDProfileInfoMap returns an empty map, as the project is not signed yet.
+func (archive ProjectArchive) BundleIDProfileInfoMap() map[string]profileutil.ProvisioningProfileInfoModel {
+	return map[string]profileutil.ProvisioningProfileInfoModel{}
+}
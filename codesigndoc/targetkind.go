@@ -0,0 +1,140 @@
+package codesigndoc
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/bitrise-io/go-xcode/plistutil"
+	"github.com/bitrise-io/go-xcode/xcarchive"
+)
+
+// Kinds of the signed targets of an archive.
+const (
+	targetKindApp             = "app"
+	targetKindAppExtension    = "app extension"
+	targetKindWidgetExtension = "widget extension"
+	targetKindAppClip         = "App Clip"
+	targetKindWatchApp        = "watchOS app"
+	targetKindWatchExtension  = "watchOS app extension"
+	targetKindWatchWidget     = "watchOS widget extension"
+)
+
+// Extension point identifiers of the extension kinds labelled specifically.
+const (
+	widgetKitExtensionPoint   = "com.apple.widgetkit-extension"
+	todayWidgetExtensionPoint = "com.apple.widget-extension"
+	watchKitExtensionPoint    = "com.apple.watchkit"
+)
+
+// Product types of the Xcode project targets.
+const (
+	applicationProductType           = "com.apple.product-type.application"
+	appExtensionProductType          = "com.apple.product-type.app-extension"
+	appClipProductType               = "com.apple.product-type.application.on-demand-install-capable"
+	watchAppProductType              = "com.apple.product-type.application.watchapp2"
+	watchContainerAppProductType     = "com.apple.product-type.application.watchapp2-container"
+	watchKitExtensionProductType     = "com.apple.product-type.watchkit2-extension"
+	extensionKitExtensionProductType = "com.apple.product-type.extensionkit-extension"
+)
+
+// targetInfo describes a signed target of an archive.
+type targetInfo struct {
+	Name string
+	Kind string
+}
+
+// targetLabel returns the bundle ID labelled with the target's name and kind, if known.
+func targetLabel(bundleID string, targets map[string]targetInfo) string {
+	target, ok := targets[bundleID]
+	if !ok {
+		return bundleID
+	}
+	return fmt.Sprintf("%s (%s, %s)", bundleID, target.Name, target.Kind)
+}
+
+// archiveTargets returns the name and kind of every signed target of the archive, by bundle ID.
+func archiveTargets(archive Archive) map[string]targetInfo {
+	targets := map[string]targetInfo{}
+
+	switch archive := archive.(type) {
+	case xcarchive.IosArchive:
+		app := archive.Application
+		targets[app.BundleIdentifier()] = targetInfo{Name: bundleName(app.Path), Kind: targetKindApp}
+		for _, extension := range app.Extensions {
+			targets[extension.BundleIdentifier()] = targetInfo{Name: bundleName(extension.Path), Kind: extensionKind(extension.InfoPlist, false)}
+		}
+
+		if app.WatchApplication != nil {
+			watchApp := *app.WatchApplication
+			targets[watchApp.BundleIdentifier()] = targetInfo{Name: bundleName(watchApp.Path), Kind: targetKindWatchApp}
+			for _, extension := range watchApp.Extensions {
+				targets[extension.BundleIdentifier()] = targetInfo{Name: bundleName(extension.Path), Kind: extensionKind(extension.InfoPlist, true)}
+			}
+		}
+
+		if app.ClipApplication != nil {
+			clipApp := *app.ClipApplication
+			targets[clipApp.BundleIdentifier()] = targetInfo{Name: bundleName(clipApp.Path), Kind: targetKindAppClip}
+		}
+	case xcarchive.MacosArchive:
+		app := archive.Application
+		targets[app.BundleIdentifier()] = targetInfo{Name: bundleName(app.Path), Kind: targetKindApp}
+		for _, extension := range app.Extensions {
+			targets[extension.BundleIdentifier()] = targetInfo{Name: bundleName(extension.Path), Kind: extensionKind(extension.InfoPlist, false)}
+		}
+	case ProjectArchive:
+		for bundleID, target := range archive.targets {
+			targets[bundleID] = target
+		}
+	}
+
+	return targets
+}
+
+// extensionKind returns the kind of an app extension by its extension point identifier.
+// The extension point of the not specifically labelled extensions is appended to the kind, i.e. "app extension: com.apple.share-services".
+func extensionKind(infoPlist plistutil.PlistData, isWatchExtension bool) string {
+	var extensionPoint string
+	if extension, ok := infoPlist.GetMapStringInterface("NSExtension"); ok {
+		extensionPoint, _ = extension.GetString("NSExtensionPointIdentifier")
+	}
+
+	switch {
+	case isWatchExtension && extensionPoint == widgetKitExtensionPoint:
+		return targetKindWatchWidget
+	case isWatchExtension:
+		return targetKindWatchExtension
+	case extensionPoint == widgetKitExtensionPoint || extensionPoint == todayWidgetExtensionPoint:
+		return targetKindWidgetExtension
+	case extensionPoint == watchKitExtensionPoint:
+		return targetKindWatchExtension
+	case extensionPoint != "":
+		return targetKindAppExtension + ": " + extensionPoint
+	default:
+		return targetKindAppExtension
+	}
+}
+
+// productTypeKind returns the kind of an Xcode project target by its product type.
+func productTypeKind(productType string) string {
+	switch productType {
+	case applicationProductType:
+		return targetKindApp
+	case appClipProductType:
+		return targetKindAppClip
+	case watchAppProductType, watchContainerAppProductType:
+		return targetKindWatchApp
+	case watchKitExtensionProductType:
+		return targetKindWatchExtension
+	case appExtensionProductType, extensionKitExtensionProductType:
+		return targetKindAppExtension
+	default:
+		return strings.TrimPrefix(productType, "com.apple.product-type.")
+	}
+}
+
+// bundleName returns the name of the bundle at the given path, i.e. "ShareExtension" for ".../ShareExtension.appex".
+func bundleName(pth string) string {
+	return strings.TrimSuffix(filepath.Base(pth), filepath.Ext(pth))
+}
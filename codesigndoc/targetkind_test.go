@@ -0,0 +1,65 @@
+package codesigndoc
+
+import (
+	"testing"
+
+	"github.com/bitrise-io/go-xcode/plistutil"
+	"github.com/bitrise-io/go-xcode/xcarchive"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestIosBaseApplication(path, bundleID, extensionPoint string) xcarchive.IosBaseApplication {
+	infoPlist := plistutil.PlistData{"CFBundleIdentifier": bundleID}
+	if extensionPoint != "" {
+		infoPlist["NSExtension"] = map[string]interface{}{"NSExtensionPointIdentifier": extensionPoint}
+	}
+	return xcarchive.IosBaseApplication{Path: path, InfoPlist: infoPlist}
+}
+
+func TestArchiveTargets(t *testing.T) {
+	archive := xcarchive.IosArchive{
+		Application: xcarchive.IosApplication{
+			IosBaseApplication: newTestIosBaseApplication("Products/Applications/Sample.app", "io.bitrise.sample", ""),
+			Extensions: []xcarchive.IosExtension{
+				{IosBaseApplication: newTestIosBaseApplication("Products/Applications/Sample.app/PlugIns/Share.appex", "io.bitrise.sample.share", "com.apple.share-services")},
+				{IosBaseApplication: newTestIosBaseApplication("Products/Applications/Sample.app/PlugIns/Widgets.appex", "io.bitrise.sample.widgets", "com.apple.widgetkit-extension")},
+			},
+			WatchApplication: &xcarchive.IosWatchApplication{
+				IosBaseApplication: newTestIosBaseApplication("Products/Applications/Sample.app/Watch/Watch.app", "io.bitrise.sample.watchkitapp", ""),
+				Extensions: []xcarchive.IosExtension{
+					{IosBaseApplication: newTestIosBaseApplication("Products/Applications/Sample.app/Watch/Watch.app/PlugIns/WatchExtension.appex", "io.bitrise.sample.watchkitapp.extension", "com.apple.watchkit")},
+					{IosBaseApplication: newTestIosBaseApplication("Products/Applications/Sample.app/Watch/Watch.app/PlugIns/WatchWidgets.appex", "io.bitrise.sample.watchkitapp.widgets", "com.apple.widgetkit-extension")},
+				},
+			},
+			ClipApplication: &xcarchive.IosClipApplication{
+				IosBaseApplication: newTestIosBaseApplication("Products/Applications/Sample.app/AppClips/Clip.app", "io.bitrise.sample.clip", ""),
+			},
+		},
+	}
+
+	require.Equal(t, map[string]targetInfo{
+		"io.bitrise.sample":                       {Name: "Sample", Kind: "app"},
+		"io.bitrise.sample.share":                 {Name: "Share", Kind: "app extension: com.apple.share-services"},
+		"io.bitrise.sample.widgets":               {Name: "Widgets", Kind: "widget extension"},
+		"io.bitrise.sample.watchkitapp":           {Name: "Watch", Kind: "watchOS app"},
+		"io.bitrise.sample.watchkitapp.extension": {Name: "WatchExtension", Kind: "watchOS app extension"},
+		"io.bitrise.sample.watchkitapp.widgets":   {Name: "WatchWidgets", Kind: "watchOS widget extension"},
+		"io.bitrise.sample.clip":                  {Name: "Clip", Kind: "App Clip"},
+	}, archiveTargets(archive))
+}
+
+func TestTargetLabel(t *testing.T) {
+	targets := map[string]targetInfo{"io.bitrise.sample.clip": {Name: "Clip", Kind: "App Clip"}}
+
+	require.Equal(t, "io.bitrise.sample.clip (Clip, App Clip)", targetLabel("io.bitrise.sample.clip", targets))
+	require.Equal(t, "io.bitrise.sample", targetLabel("io.bitrise.sample", targets))
+}
+
+func TestProductTypeKind(t *testing.T) {
+	require.Equal(t, "app", productTypeKind("com.apple.product-type.application"))
+	require.Equal(t, "App Clip", productTypeKind("com.apple.product-type.application.on-demand-install-capable"))
+	require.Equal(t, "watchOS app", productTypeKind("com.apple.product-type.application.watchapp2"))
+	require.Equal(t, "watchOS app extension", productTypeKind("com.apple.product-type.watchkit2-extension"))
+	require.Equal(t, "app extension", productTypeKind("com.apple.product-type.app-extension"))
+	require.Equal(t, "framework", productTypeKind("com.apple.product-type.framework"))
+}
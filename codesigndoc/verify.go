@@ -0,0 +1,85 @@
+package codesigndoc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bitrise-io/codesigndoc/codesign"
+	"github.com/bitrise-io/go-utils/sliceutil"
+	"github.com/bitrise-io/go-xcode/certificateutil"
+	"github.com/bitrise-io/go-xcode/export"
+	"github.com/bitrise-io/go-xcode/plistutil"
+	"github.com/bitrise-io/go-xcode/profileutil"
+)
+
+// ExportMethodVerification is the result of verifying code signing files for an export method.
+type ExportMethodVerification struct {
+	ExportMethod string
+	// Certificates are the certificates which can sign every bundle ID with the export method.
+	Certificates []certificateutil.CertificateInfoModel
+	// UncoveredBundleIDs are the bundle IDs without a matching profile of a given certificate.
+	UncoveredBundleIDs []string
+}
+
+// Covered returns true if there is a certificate which can sign every bundle ID with the export method.
+func (verification ExportMethodVerification) Covered() bool {
+	return len(verification.Certificates) > 0
+}
+
+// VerifyCodesignFiles checks for every export method, that every bundle ID of the archive is covered
+// by a provisioning profile whose certificates are in the given certificates,
+// using the same matching as collecting the export code signing files.
+func VerifyCodesignFiles(archive Archive, isMacArchive bool, certificates []certificateutil.CertificateInfoModel, profiles []profileutil.ProvisioningProfileInfoModel, exportMethods []string) ([]ExportMethodVerification, error) {
+	availableExportMethods := codesign.ExportMethods(isMacArchive)
+	for _, exportMethod := range exportMethods {
+		if !sliceutil.IsStringInSlice(exportMethod, availableExportMethods) {
+			return nil, fmt.Errorf("invalid export method (%s) for the archive, available export methods: %s", exportMethod, strings.Join(availableExportMethods, ", "))
+		}
+	}
+
+	codeSignGroups := collectExportSelectableCodeSignGroups(archive, certificates, profiles)
+
+	var verifications []ExportMethodVerification
+	for _, exportMethod := range exportMethods {
+		profileExportType, err := codesign.ProfileExportType(exportMethod)
+		if err != nil {
+			return nil, err
+		}
+		exportMethodFilter := export.CreateExportMethodSelectableCodeSignGroupFilter(profileExportType)
+
+		verification := ExportMethodVerification{ExportMethod: exportMethod}
+		for _, group := range export.FilterSelectableCodeSignGroups(codeSignGroups, exportMethodFilter) {
+			verification.Certificates = append(verification.Certificates, group.Certificate)
+		}
+
+		// Every bundle ID can be covered separately while no certificate covers all of them,
+		// so the bundle IDs without any matching profile are looked up one by one.
+		verification.UncoveredBundleIDs = uncoveredBundleIDs(archive, certificates, profiles, exportMethodFilter)
+
+		verifications = append(verifications, verification)
+	}
+
+	return verifications, nil
+}
+
+// uncoveredBundleIDs returns the bundle IDs of the archive without a matching provisioning profile of the given certificates,
+// which also passes the given filters.
+func uncoveredBundleIDs(archive Archive, certificates []certificateutil.CertificateInfoModel, profiles []profileutil.ProvisioningProfileInfoModel, filters ...export.SelectableCodeSignGroupFilter) []string {
+	var bundleIDs []string
+	for bundleID, entitlements := range archive.BundleIDEntitlementsMap() {
+		bundleIDFilters := append([]export.SelectableCodeSignGroupFilter{
+			export.CreateEntitlementsSelectableCodeSignGroupFilter(map[string]plistutil.PlistData{bundleID: entitlements}),
+		}, filters...)
+		if !archive.IsXcodeManaged() {
+			bundleIDFilters = append(bundleIDFilters, export.CreateNotXcodeManagedSelectableCodeSignGroupFilter())
+		}
+
+		bundleIDGroups := export.CreateSelectableCodeSignGroups(certificates, profiles, []string{bundleID})
+		if len(export.FilterSelectableCodeSignGroups(bundleIDGroups, bundleIDFilters...)) == 0 {
+			bundleIDs = append(bundleIDs, bundleID)
+		}
+	}
+	sort.Strings(bundleIDs)
+	return bundleIDs
+}
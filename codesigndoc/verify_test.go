@@ -0,0 +1,71 @@
+package codesigndoc
+
+import (
+	"testing"
+
+	"github.com/bitrise-io/go-xcode/certificateutil"
+	"github.com/bitrise-io/go-xcode/exportoptions"
+	"github.com/bitrise-io/go-xcode/plistutil"
+	"github.com/bitrise-io/go-xcode/profileutil"
+	"github.com/stretchr/testify/require"
+)
+
+type testArchive struct {
+	bundleIDEntitlementsMap map[string]plistutil.PlistData
+}
+
+func (archive testArchive) BundleIDEntitlementsMap() map[string]plistutil.PlistData {
+	return archive.bundleIDEntitlementsMap
+}
+
+func (archive testArchive) IsXcodeManaged() bool {
+	return true
+}
+
+func (archive testArchive) SigningIdentity() string {
+	return "Apple Development"
+}
+
+func (archive testArchive) BundleIDProfileInfoMap() map[string]profileutil.ProvisioningProfileInfoModel {
+	return map[string]profileutil.ProvisioningProfileInfoModel{}
+}
+
+func TestVerifyCodesignFiles(t *testing.T) {
+	development := certificateutil.CertificateInfoModel{CommonName: "Apple Development: Test User (ABCD1234)", Serial: "1", TeamID: "ABCD1234"}
+	distribution := certificateutil.CertificateInfoModel{CommonName: "Apple Distribution: Test Team (ABCD1234)", Serial: "2", TeamID: "ABCD1234"}
+
+	archive := testArchive{bundleIDEntitlementsMap: map[string]plistutil.PlistData{
+		"io.bitrise.app":       {},
+		"io.bitrise.app.today": {},
+	}}
+
+	profiles := []profileutil.ProvisioningProfileInfoModel{
+		{Name: "Wildcard Development", UUID: "uuid-1", BundleID: "*", ExportType: exportoptions.MethodDevelopment, DeveloperCertificates: []certificateutil.CertificateInfoModel{development}},
+		{Name: "App Store", UUID: "uuid-2", BundleID: "io.bitrise.app", ExportType: exportoptions.MethodAppStore, DeveloperCertificates: []certificateutil.CertificateInfoModel{distribution}},
+		{Name: "Ad Hoc", UUID: "uuid-3", BundleID: "io.bitrise.app", ExportType: exportoptions.MethodAdHoc, DeveloperCertificates: []certificateutil.CertificateInfoModel{distribution}},
+		{Name: "Today Ad Hoc", UUID: "uuid-4", BundleID: "io.bitrise.app.today", ExportType: exportoptions.MethodAdHoc, DeveloperCertificates: []certificateutil.CertificateInfoModel{development}},
+	}
+
+	verifications, err := VerifyCodesignFiles(archive, false, []certificateutil.CertificateInfoModel{development, distribution}, profiles, []string{"development", "app-store", "ad-hoc"})
+	require.NoError(t, err)
+	require.Equal(t, 3, len(verifications))
+
+	require.True(t, verifications[0].Covered())
+	require.Equal(t, []certificateutil.CertificateInfoModel{development}, verifications[0].Certificates)
+	require.Nil(t, verifications[0].UncoveredBundleIDs)
+
+	require.False(t, verifications[1].Covered())
+	require.Equal(t, []string{"io.bitrise.app.today"}, verifications[1].UncoveredBundleIDs)
+
+	// Both bundle IDs have an ad-hoc profile, but of different certificates.
+	require.False(t, verifications[2].Covered())
+	require.Nil(t, verifications[2].UncoveredBundleIDs)
+
+	verifications, err = VerifyCodesignFiles(archive, false, []certificateutil.CertificateInfoModel{distribution}, profiles, []string{"development"})
+	require.NoError(t, err)
+	require.False(t, verifications[0].Covered())
+	require.Equal(t, []string{"io.bitrise.app", "io.bitrise.app.today"}, verifications[0].UncoveredBundleIDs)
+
+	_, err = VerifyCodesignFiles(archive, false, nil, nil, []string{"developer-id"})
+	require.Error(t, err)
+}
@@ -3,17 +3,16 @@ package codesigndoc
 import (
 	"fmt"
 
-	"github.com/bitrise-io/codesigndoc/codesign"
-	"github.com/bitrise-io/codesigndoc/models"
+	"github.com/bitrise-io/codesigndoc/buildlog"
 	"github.com/bitrise-io/codesigndoc/xcode"
 	"github.com/bitrise-io/go-utils/colorstring"
 	"github.com/bitrise-io/go-utils/log"
-	"github.com/bitrise-io/go-utils/stringutil"
 	"github.com/bitrise-io/go-xcode/utility"
 )
 
 // BuildXcodeArchive builds an Xcode archive.
-func BuildXcodeArchive(xcodeCmd xcode.CommandModel, handleBuildLog func(string) error) (archivePath string, err error) {
+// The provisioningUpdatesFlag is suggested by the fixes of the code signing failures, see buildlog.Diagnose.
+func BuildXcodeArchive(xcodeCmd xcode.CommandModel, provisioningUpdatesFlag string, handleBuildLog func(string) error) (archivePath string, err error) {
 	// Output tools versions
 	xcodebuildVersion, err := utility.GetXcodeVersion()
 	if err != nil {
@@ -39,8 +38,7 @@ func BuildXcodeArchive(xcodeCmd xcode.CommandModel, handleBuildLog func(string)
 	}()
 
 	if err != nil {
-		log.Warnf("Last lines of the build log:")
-		fmt.Println(stringutil.LastNLines(xcodebuildOutput, 15))
+		buildlog.PrintFailure(xcodebuildOutput, provisioningUpdatesFlag)
 
 		log.Infof(colorstring.Yellow("Please check the build log to see what caused the error."))
 		fmt.Println()
@@ -55,14 +53,3 @@ func BuildXcodeArchive(xcodeCmd xcode.CommandModel, handleBuildLog func(string)
 
 	return archivePath, nil
 }
-
-// CodesigningFilesForXCodeProject ...
-func CodesigningFilesForXCodeProject(archivePath string, certificatesOnly bool, isAskForPassword bool) (models.Certificates, []models.ProvisioningProfile, error) {
-	// If certificatesOnly is set, CollectCodesignFiles returns an empty slice for profiles
-	certificatesToExport, profilesToExport, err := CollectCodesignFiles(archivePath, certificatesOnly)
-	if err != nil {
-		return models.Certificates{}, nil, err
-	}
-
-	return codesign.ExportCodesigningFiles(certificatesToExport, profilesToExport, isAskForPassword)
-}
@@ -3,8 +3,10 @@ package codesigndocuitests
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 
 	"github.com/bitrise-io/codesigndoc/codesign"
+	"github.com/bitrise-io/codesigndoc/utility"
 	"github.com/bitrise-io/go-utils/log"
 	"github.com/bitrise-io/go-xcode/certificateutil"
 	"github.com/bitrise-io/go-xcode/export"
@@ -19,10 +21,32 @@ const collectCodesigningFilesInfo = `To collect available code sign files, we se
 
 // CollectCodesignFiles collects the codesigning files for the UITests-Runner.app
 // and filters them for the specified export method.
-func CollectCodesignFiles(buildPath string, certificatesOnly bool) ([]certificateutil.CertificateInfoModel, []profileutil.ProvisioningProfileInfoModel, error) {
-	// Find out the XcArchive type
+// The platform is the test target's platform, detected by utility.BuildableTargetPlatform.
+// The selectPolicy is used to choose from multiple qualifying certificates or profiles, if set.
+// If teamID is not empty, only the given team's code signing files are collected.
+func CollectCodesignFiles(buildPath string, platform utility.Platform, certificatesOnly bool, selectPolicy codesign.SelectPolicy, teamID string) ([]certificateutil.CertificateInfoModel, []profileutil.ProvisioningProfileInfoModel, error) {
+	var testRunners []*TestRunner
+	if !certificatesOnly {
+		runners, err := NewTestRunners(buildPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		testRunners = runners
+	}
+
+	// Mac Catalyst test targets are built with the iOS SDK, but signed as macOS bundles
+	isMacOS := platform == utility.OSX
+	if len(testRunners) > 0 {
+		isMacOS = testRunners[0].IsMacOS
+	}
+
+	// Find out the test target type
 	certificateType := codesign.IOSCertificate
 	profileType := profileutil.ProfileTypeIos
+	if isMacOS {
+		certificateType = codesign.MacOSCertificate
+		profileType = profileutil.ProfileTypeMacOs
+	}
 
 	// Certificates
 	certificates, err := codesign.InstalledCertificates(certificateType)
@@ -41,33 +65,45 @@ func CollectCodesignFiles(buildPath string, certificatesOnly bool) ([]certificat
 		return nil, nil, fmt.Errorf("failed to list installed provisioning profiles, error: %s", err)
 	}
 
+	// tvOS profiles are installed next to the iOS ones (*.mobileprovision)
+	if platform == utility.TvOS && !isMacOS {
+		profiles = filterProfilesByType(profiles, profileutil.ProfileTypeTvOs)
+	}
+
 	log.Debugf("Installed profiles:")
 	for _, profileInfo := range profiles {
 		log.Debugf(profileInfo.String(certificates...))
 	}
 
-	return getFilesToExport(buildPath, certificates, profiles, certificatesOnly)
+	if teamID != "" {
+		certificates = codesign.FilterCertificatesByTeam(certificates, teamID)
+		profiles = codesign.FilterProfilesByTeam(profiles, teamID)
+
+		log.Debugf("Code signing files of team %s: %d certificates, %d profiles", teamID, len(certificates), len(profiles))
+	}
+
+	return getFilesToExport(testRunners, isMacOS, certificates, profiles, certificatesOnly, selectPolicy, teamID)
 }
 
-func getFilesToExport(buildPath string, installedCertificates []certificateutil.CertificateInfoModel, installedProfiles []profileutil.ProvisioningProfileInfoModel, certificatesOnly bool) ([]certificateutil.CertificateInfoModel, []profileutil.ProvisioningProfileInfoModel, error) {
+func getFilesToExport(testRunners []*TestRunner, isMacOS bool, installedCertificates []certificateutil.CertificateInfoModel, installedProfiles []profileutil.ProvisioningProfileInfoModel, certificatesOnly bool, selectPolicy codesign.SelectPolicy, teamID string) ([]certificateutil.CertificateInfoModel, []profileutil.ProvisioningProfileInfoModel, error) {
 	var certificatesToExport []certificateutil.CertificateInfoModel
 	var profilesToExport []profileutil.ProvisioningProfileInfoModel
 
 	if certificatesOnly {
-		exportCertificate, err := collectExportCertificate(installedCertificates)
+		exportCertificate, err := collectExportCertificate(isMacOS, installedCertificates, selectPolicy)
 		if err != nil {
 			return nil, nil, err
 		}
 
 		certificatesToExport = append(certificatesToExport, exportCertificate...)
 	} else {
-		testRunners, err := NewIOSTestRunners(buildPath)
-		if err != nil {
-			return nil, nil, err
-		}
-
 		for _, testRunner := range testRunners {
-			certsToExport, profsToExport, err := collectCertificatesAndProfiles(*testRunner, installedCertificates, installedProfiles)
+			if teamID != "" && testRunner.ProvisioningProfile.TeamID != teamID {
+				fmt.Println()
+				log.Warnf("🚨  The UITest target (%s) was signed by team %s (%s), which differs from the requested team (%s).", filepath.Base(testRunner.Path), testRunner.ProvisioningProfile.TeamName, testRunner.ProvisioningProfile.TeamID, teamID)
+			}
+
+			certsToExport, profsToExport, err := collectCertificatesAndProfiles(*testRunner, installedCertificates, installedProfiles, selectPolicy)
 			if err != nil {
 				return nil, nil, err
 			}
@@ -81,18 +117,31 @@ func getFilesToExport(buildPath string, installedCertificates []certificateutil.
 	return certificatesToExport, profilesToExport, nil
 }
 
-func collectCertificatesAndProfiles(testRunner IOSTestRunner, installedCertificates []certificateutil.CertificateInfoModel,
-	installedProfiles []profileutil.ProvisioningProfileInfoModel) ([]certificateutil.CertificateInfoModel, []profileutil.ProvisioningProfileInfoModel, error) {
+// filterProfilesByType returns the profiles of the given platform.
+func filterProfilesByType(profiles []profileutil.ProvisioningProfileInfoModel, profileType profileutil.ProfileType) []profileutil.ProvisioningProfileInfoModel {
+	var filtered []profileutil.ProvisioningProfileInfoModel
+	for _, profile := range profiles {
+		if profile.Type == profileType {
+			filtered = append(filtered, profile)
+		}
+	}
+	return filtered
+}
+
+func collectCertificatesAndProfiles(testRunner TestRunner, installedCertificates []certificateutil.CertificateInfoModel,
+	installedProfiles []profileutil.ProvisioningProfileInfoModel, selectPolicy codesign.SelectPolicy) ([]certificateutil.CertificateInfoModel, []profileutil.ProvisioningProfileInfoModel, error) {
 
-	groups, err := collectExportCodeSignGroups(testRunner, installedCertificates, installedProfiles)
+	groups, err := collectExportCodeSignGroups(testRunner, installedCertificates, installedProfiles, selectPolicy)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	var exportCodeSignGroups []export.CodeSignGroup
 	for _, group := range groups {
-		exportCodeSignGroup, ok := group.(*export.IosCodeSignGroup)
-		if ok {
+		switch exportCodeSignGroup := group.(type) {
+		case *export.IosCodeSignGroup:
+			exportCodeSignGroups = append(exportCodeSignGroups, exportCodeSignGroup)
+		case *export.MacCodeSignGroup:
 			exportCodeSignGroups = append(exportCodeSignGroups, exportCodeSignGroup)
 		}
 	}
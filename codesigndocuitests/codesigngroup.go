@@ -65,12 +65,20 @@ func printCodesignGroup(group export.CodeSignGroup) {
 	}
 }
 
+// codeSignMethods returns the code signing methods available for the UITest target's platform.
+func codeSignMethods(isMacOS bool) []string {
+	if isMacOS {
+		return []string{"development", "app-store", "developer-id"}
+	}
+	return []string{"development", "app-store", "ad-hoc", "enterprise"}
+}
+
 // collectExportCertificate returns the certificate to use for the UITest-Runner.
-func collectExportCertificate(installedCertificates []certificateutil.CertificateInfoModel) ([]certificateutil.CertificateInfoModel, error) {
+func collectExportCertificate(isMacOS bool, installedCertificates []certificateutil.CertificateInfoModel, selectPolicy codesign.SelectPolicy) ([]certificateutil.CertificateInfoModel, error) {
 	var selectedCertificates []certificateutil.CertificateInfoModel
 
 	// Codesign method
-	codesignMethods := []string{"development", "app-store", "ad-hoc", "enterprise"}
+	codesignMethods := codeSignMethods(isMacOS)
 
 	// Asking the user over and over until we find a valid certificate for the selected export method.
 	for searchingValidCertificate := true; searchingValidCertificate; {
@@ -82,7 +90,7 @@ func collectExportCertificate(installedCertificates []certificateutil.Certificat
 
 		log.Debugf("selected export method: %v", selectedCodeSignMethod)
 
-		certs, err := selectFilteredCertificates(selectedCodeSignMethod, "", installedCertificates)
+		certs, err := selectFilteredCertificates(selectedCodeSignMethod, "", installedCertificates, selectPolicy)
 		if err != nil {
 			return nil, err
 		}
@@ -100,7 +108,9 @@ func collectExportCertificate(installedCertificates []certificateutil.Certificat
 	return selectedCertificates, nil
 }
 
-func selectFilteredCertificates(selectedCodeSignMethod, selectedTeam string, installedCertificates []certificateutil.CertificateInfoModel) ([]certificateutil.CertificateInfoModel, error) {
+// selectFilteredCertificates returns the certificate to use for the selected code signing method.
+// If the selectPolicy is set, the certificate is chosen by the policy from every team's certificates instead of asking.
+func selectFilteredCertificates(selectedCodeSignMethod, selectedTeam string, installedCertificates []certificateutil.CertificateInfoModel, selectPolicy codesign.SelectPolicy) ([]certificateutil.CertificateInfoModel, error) {
 	var selectedCertificates []certificateutil.CertificateInfoModel
 	var certsForSelectedCodeSign []certificateutil.CertificateInfoModel
 	var err error
@@ -129,6 +139,15 @@ func selectFilteredCertificates(selectedCodeSignMethod, selectedTeam string, ins
 		return nil, nil
 	}
 
+	if selectedTeam == "" && selectPolicy.IsSet() {
+		fmt.Println()
+		certificate, err := selectPolicy.SelectCertificate(certsForSelectedCodeSign)
+		if err != nil {
+			return nil, err
+		}
+		return append(selectedCertificates, certificate), nil
+	}
+
 	// If we already selected a team, we can skip it. (e.g. mac app-store export)
 	if selectedTeam == "" {
 		// Use different team for export than archive.
@@ -178,7 +197,8 @@ func selectFilteredCertificates(selectedCodeSignMethod, selectedTeam string, ins
 }
 
 // collectExportCodeSignGroups returns the codesign groups required for the UITest target with the selected code signing methods.
-func collectExportCodeSignGroups(testRunner IOSTestRunner, installedCertificates []certificateutil.CertificateInfoModel, installedProfiles []profileutil.ProvisioningProfileInfoModel) ([]export.CodeSignGroup, error) {
+// The selectPolicy is used to choose from multiple qualifying certificates or profiles, if set.
+func collectExportCodeSignGroups(testRunner TestRunner, installedCertificates []certificateutil.CertificateInfoModel, installedProfiles []profileutil.ProvisioningProfileInfoModel, selectPolicy codesign.SelectPolicy) ([]export.CodeSignGroup, error) {
 	var collectedCodeSignGroups []export.CodeSignGroup
 
 	codeSignGroups := collectExportSelectableCodeSignGroups(testRunner, installedCertificates, installedProfiles)
@@ -190,9 +210,8 @@ func collectExportCodeSignGroups(testRunner IOSTestRunner, installedCertificates
 	fmt.Println()
 	log.Infof("Code signing for target with %s bundle ID", strings.TrimRight(testRunnerID, "-Runner"))
 
-	codeSignMethods := []string{"development", "app-store", "ad-hoc", "enterprise"}
 	for {
-		selectedCodeSignMethod, err := goinp.SelectFromStringsWithDefault("Select the code signing method", 1, codeSignMethods)
+		selectedCodeSignMethod, err := goinp.SelectFromStringsWithDefault("Select the code signing method", 1, codeSignMethods(testRunner.IsMacOS))
 		if err != nil {
 			return nil, fmt.Errorf("failed to read input: %s", err)
 		}
@@ -235,8 +254,15 @@ func collectExportCodeSignGroups(testRunner IOSTestRunner, installedCertificates
 			certificateOptions = append(certificateOptions, certificateOption)
 		}
 
+		// The select policy is applied to a single candidate too, so a team policy never selects another team's certificate.
 		var selectedCertificateOption string
-		if len(certificateOptions) == 1 {
+		if selectPolicy.IsSet() {
+			certificate, err := selectPolicy.SelectCertificate(certificates)
+			if err != nil {
+				return nil, err
+			}
+			selectedCertificateOption = fmt.Sprintf("%s [%s] - development team: %s", certificate.CommonName, certificate.Serial, certificate.TeamName)
+		} else if len(certificateOptions) == 1 {
 			selectedCertificateOption = certificateOptions[0]
 
 			fmt.Printf("Codesign Identity for %s signing: %s\n", selectedCodeSignMethod, selectedCertificateOption)
@@ -285,7 +311,15 @@ func collectExportCodeSignGroups(testRunner IOSTestRunner, installedCertificates
 			}
 
 			var selectedProfileOption string
-			if len(profileOptions) == 1 {
+			if selectPolicy.IsSet() {
+				fmt.Println()
+				log.Printf("Selecting the Provisioning Profile to sign target with bundle ID: %s", bundleID)
+				profile, err := selectPolicy.SelectProfile(profiles)
+				if err != nil {
+					return nil, err
+				}
+				selectedProfileOption = fmt.Sprintf("%s (%s)", profile.Name, profile.UUID)
+			} else if len(profileOptions) == 1 {
 				selectedProfileOption = profileOptions[0]
 
 				fmt.Printf("Provisioning Profile to sign target (%s): %s\n", bundleID, selectedProfileOption)
@@ -311,7 +345,12 @@ func collectExportCodeSignGroups(testRunner IOSTestRunner, installedCertificates
 			return nil, fmt.Errorf("failed to find Provisioning Profiles for UITest target signing")
 		}
 
-		collectedCodeSignGroup := export.NewIOSGroup(*selectedCertificate, selectedBundleIDProfileMap)
+		var collectedCodeSignGroup export.CodeSignGroup
+		if testRunner.IsMacOS {
+			collectedCodeSignGroup = export.NewMacGroup(*selectedCertificate, nil, selectedBundleIDProfileMap)
+		} else {
+			collectedCodeSignGroup = export.NewIOSGroup(*selectedCertificate, selectedBundleIDProfileMap)
+		}
 
 		fmt.Println()
 		log.Infof("Codesign settings will be used for %s method:", codesignMethod(collectedCodeSignGroup))
@@ -335,7 +374,7 @@ func collectExportCodeSignGroups(testRunner IOSTestRunner, installedCertificates
 }
 
 // collectExportSelectableCodeSignGroups returns every possible codesign group which can be used to sign the UITest-Runner.
-func collectExportSelectableCodeSignGroups(testRunner IOSTestRunner, installedCertificates []certificateutil.CertificateInfoModel, installedProfiles []profileutil.ProvisioningProfileInfoModel) []export.SelectableCodeSignGroup {
+func collectExportSelectableCodeSignGroups(testRunner TestRunner, installedCertificates []certificateutil.CertificateInfoModel, installedProfiles []profileutil.ProvisioningProfileInfoModel) []export.SelectableCodeSignGroup {
 	bundleIDEEntitlementsMap := testRunner.BundleIDEntitlementsMap()
 
 	var bundleIDs []string
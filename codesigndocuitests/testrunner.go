@@ -0,0 +1,153 @@
+package codesigndocuitests
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/bitrise-io/go-utils/command"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/bitrise-io/go-xcode/plistutil"
+	"github.com/bitrise-io/go-xcode/profileutil"
+)
+
+// TestRunner is a code signed bundle of the xcodebuild build-for-testing command's output.
+type TestRunner struct {
+	Path                string
+	IsMacOS             bool
+	InfoPlist           plistutil.PlistData
+	Entitlements        plistutil.PlistData
+	ProvisioningProfile profileutil.ProvisioningProfileInfoModel
+}
+
+// NewTestRunners returns the code signed bundles of the xcodebuild build-for-testing command's output:
+// every *-Runner.app, the tested applications and their embedded app extensions and test bundles.
+func NewTestRunners(path string) ([]*TestRunner, error) {
+	bundlePaths, err := signedBundlePaths(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var testRunners []*TestRunner
+	for _, bundlePath := range bundlePaths {
+		testRunner, err := newTestRunner(bundlePath)
+		if err != nil {
+			return nil, err
+		}
+		testRunners = append(testRunners, testRunner)
+	}
+
+	return testRunners, nil
+}
+
+func newTestRunner(testRunnerPath string) (*TestRunner, error) {
+	isMacOS, err := isMacOSBundle(testRunnerPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var infoPlist plistutil.PlistData
+	{
+		infoPlistPath := filepath.Join(bundleContentsPath(testRunnerPath, isMacOS), "Info.plist")
+		if exist, err := pathutil.IsPathExists(infoPlistPath); err != nil {
+			return nil, fmt.Errorf("failed to check if Info.plist exists at: %s, error: %s", infoPlistPath, err)
+		} else if !exist {
+			return nil, fmt.Errorf("an Info.plist not exists at: %s", infoPlistPath)
+		}
+
+		plist, err := plistutil.NewPlistDataFromFile(infoPlistPath)
+		if err != nil {
+			return nil, err
+		}
+
+		infoPlist = plist
+	}
+
+	var provisioningProfile profileutil.ProvisioningProfileInfoModel
+	{
+		provisioningProfilePath := embeddedProfilePath(testRunnerPath, isMacOS)
+		if exist, err := pathutil.IsPathExists(provisioningProfilePath); err != nil {
+			return nil, fmt.Errorf("failed to check if profile exists at: %s, error: %s", provisioningProfilePath, err)
+		} else if !exist {
+			return nil, fmt.Errorf("profile not exists at: %s", provisioningProfilePath)
+		}
+
+		profile, err := profileutil.NewProvisioningProfileInfoFromFile(provisioningProfilePath)
+		if err != nil {
+			return nil, err
+		}
+		provisioningProfile = profile
+	}
+
+	var entitlements plistutil.PlistData
+	{
+		cmd := command.New("codesign", "-d", "--entitlements", "-", testRunnerPath)
+		out, err := cmd.RunAndReturnTrimmedOutput()
+		if err != nil {
+			return nil, err
+		}
+
+		// The codesign -d --entitlements command's output contains unnecessary characters before the valid xml
+		// We need to trim them before parsing the xml
+		outSplit := strings.Split(out, "<?xml version")
+		if len(outSplit) > 1 {
+			out = outSplit[1]
+		}
+
+		entitlements, err = plistutil.NewPlistDataFromContent(out)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &TestRunner{
+		Path:                testRunnerPath,
+		IsMacOS:             isMacOS,
+		InfoPlist:           infoPlist,
+		Entitlements:        entitlements,
+		ProvisioningProfile: provisioningProfile,
+	}, nil
+}
+
+// BundleIDEntitlementsMap returns the entitlements of the bundle by its bundle ID, read from its Info.plist.
+// The bundle ID of the profile is not used, as it is a wildcard for the wildcard and the Xcode managed profiles.
+func (runner TestRunner) BundleIDEntitlementsMap() map[string]plistutil.PlistData {
+	bundleIDEntitlementsMap := map[string]plistutil.PlistData{}
+
+	bundleID, _ := runner.InfoPlist.GetString("CFBundleIdentifier")
+	bundleIDEntitlementsMap[bundleID] = runner.ProvisioningProfile.Entitlements
+
+	return bundleIDEntitlementsMap
+}
+
+// IsXcodeManaged ...
+func (runner TestRunner) IsXcodeManaged() bool {
+	return runner.ProvisioningProfile.IsXcodeManaged()
+}
+
+// isMacOSBundle returns true if the given bundle has the macOS bundle layout (Info.plist under Contents/),
+// used by the macOS and Mac Catalyst builds.
+func isMacOSBundle(bundlePath string) (bool, error) {
+	infoPlistPath := filepath.Join(bundlePath, "Contents", "Info.plist")
+	exist, err := pathutil.IsPathExists(infoPlistPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to check if Info.plist exists at: %s, error: %s", infoPlistPath, err)
+	}
+	return exist, nil
+}
+
+// bundleContentsPath returns the directory of the given bundle which holds the Info.plist, the embedded profile and the PlugIns.
+func bundleContentsPath(bundlePath string, isMacOS bool) string {
+	if isMacOS {
+		return filepath.Join(bundlePath, "Contents")
+	}
+	return bundlePath
+}
+
+// embeddedProfilePath returns the path of the provisioning profile embedded in the given bundle.
+func embeddedProfilePath(bundlePath string, isMacOS bool) string {
+	if isMacOS {
+		return filepath.Join(bundlePath, "Contents", "embedded.provisionprofile")
+	}
+	return filepath.Join(bundlePath, "embedded.mobileprovision")
+}
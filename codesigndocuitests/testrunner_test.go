@@ -0,0 +1,24 @@
+package codesigndocuitests
+
+import (
+	"testing"
+
+	"github.com/bitrise-io/go-xcode/plistutil"
+	"github.com/bitrise-io/go-xcode/profileutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestRunner_BundleIDEntitlementsMap(t *testing.T) {
+	entitlements := plistutil.PlistData{"com.apple.developer.team-identifier": "ABCD1234"}
+	runner := TestRunner{
+		Path:      "Debug-iphoneos/SampleUITests-Runner.app",
+		InfoPlist: plistutil.PlistData{"CFBundleIdentifier": "io.bitrise.Sample.UITests.xctrunner"},
+		ProvisioningProfile: profileutil.ProvisioningProfileInfoModel{
+			Name:         "iOS Team Provisioning Profile: *",
+			BundleID:     "*",
+			Entitlements: entitlements,
+		},
+	}
+
+	require.Equal(t, map[string]plistutil.PlistData{"io.bitrise.Sample.UITests.xctrunner": entitlements}, runner.BundleIDEntitlementsMap())
+}
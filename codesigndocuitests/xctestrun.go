@@ -0,0 +1,240 @@
+package codesigndocuitests
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/bitrise-io/go-xcode/plistutil"
+)
+
+const (
+	xcTestRunTestRootPlaceholder = "__TESTROOT__"
+	xcTestRunTestHostPlaceholder = "__TESTHOST__"
+)
+
+// xcTestRunTarget is a test target entry of an .xctestrun file, with the placeholder paths resolved.
+type xcTestRunTarget struct {
+	Name                  string
+	TestHostPath          string
+	TestBundlePath        string
+	UITargetAppPath       string
+	DependentProductPaths []string
+	IsUITestBundle        bool
+}
+
+// parseXCTestRun returns the test targets of every test configuration in the given .xctestrun file.
+// Both the format version 1 (test targets on the top level) and version 2 (TestConfigurations, used by test plans) are supported.
+func parseXCTestRun(pth string) ([]xcTestRunTarget, error) {
+	xcTestRun, err := plistutil.NewPlistDataFromFile(pth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse xctestrun file (%s), error: %s", pth, err)
+	}
+
+	testRoot := filepath.Dir(pth)
+
+	var targetsData []plistutil.PlistData
+	if configurations, ok := xcTestRun.GetMapStringInterfaceArray("TestConfigurations"); ok {
+		for _, configuration := range configurations {
+			configurationTargets, ok := configuration.GetMapStringInterfaceArray("TestTargets")
+			if !ok {
+				continue
+			}
+			targetsData = append(targetsData, configurationTargets...)
+		}
+	} else {
+		var names []string
+		for name := range xcTestRun {
+			if !strings.HasPrefix(name, "__") {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			targetData, ok := xcTestRun.GetMapStringInterface(name)
+			if !ok {
+				continue
+			}
+			if _, ok := targetData.GetString("BlueprintName"); !ok {
+				targetData["BlueprintName"] = name
+			}
+			targetsData = append(targetsData, targetData)
+		}
+	}
+
+	var targets []xcTestRunTarget
+	for _, targetData := range targetsData {
+		name, _ := targetData.GetString("BlueprintName")
+		testHostPath, _ := targetData.GetString("TestHostPath")
+		testBundlePath, _ := targetData.GetString("TestBundlePath")
+		uiTargetAppPath, _ := targetData.GetString("UITargetAppPath")
+		dependentProductPaths, _ := targetData.GetStringArray("DependentProductPaths")
+		isUITestBundle, _ := targetData.GetBool("IsUITestBundle")
+
+		testHostPath = resolveXCTestRunPath(testHostPath, testRoot, "")
+
+		target := xcTestRunTarget{
+			Name:            name,
+			TestHostPath:    testHostPath,
+			TestBundlePath:  resolveXCTestRunPath(testBundlePath, testRoot, testHostPath),
+			UITargetAppPath: resolveXCTestRunPath(uiTargetAppPath, testRoot, testHostPath),
+			IsUITestBundle:  isUITestBundle,
+		}
+		for _, dependentProductPath := range dependentProductPaths {
+			target.DependentProductPaths = append(target.DependentProductPaths, resolveXCTestRunPath(dependentProductPath, testRoot, testHostPath))
+		}
+
+		targets = append(targets, target)
+	}
+
+	return targets, nil
+}
+
+// resolveXCTestRunPath replaces the __TESTROOT__ and __TESTHOST__ placeholders of an .xctestrun path.
+func resolveXCTestRunPath(pth, testRoot, testHost string) string {
+	if pth == "" {
+		return ""
+	}
+
+	pth = strings.Replace(pth, xcTestRunTestRootPlaceholder, testRoot, 1)
+	if testHost != "" {
+		pth = strings.Replace(pth, xcTestRunTestHostPlaceholder, testHost, 1)
+	}
+	return filepath.Clean(pth)
+}
+
+// signedBundlePaths returns the code signed bundles of a build-for-testing output, which need code signing files to run the tests on a device.
+// The bundles are collected from every .xctestrun file (every test plan and configuration) found in the build directory:
+// the test hosts (*-Runner.app for UI tests), the UI test target applications and the dependent applications,
+// with their app extensions and test bundles nested under PlugIns/ (Contents/PlugIns/ for macOS bundles).
+// If no .xctestrun file found, the *-Runner.app bundles are collected from the build directory.
+// Only the test runners and the bundles with an embedded provisioning profile are returned.
+// It fails only if neither a test runner nor a test host application is found, as unit tests are hosted by the application.
+func signedBundlePaths(buildPath string) ([]string, error) {
+	xcTestRunPaths, err := filepath.Glob(filepath.Join(pathutil.EscapeGlobPath(buildPath), "*.xctestrun"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(xcTestRunPaths)
+
+	var runnerPaths, appPaths []string
+	if len(xcTestRunPaths) == 0 {
+		log.Debugf("No .xctestrun file found in %s, searching for *-Runner.app", buildPath)
+
+		for _, pattern := range []string{"*-Runner.app", filepath.Join("*", "*-Runner.app")} {
+			paths, err := filepath.Glob(filepath.Join(pathutil.EscapeGlobPath(buildPath), pattern))
+			if err != nil {
+				return nil, err
+			}
+			runnerPaths = append(runnerPaths, paths...)
+		}
+	} else {
+		for _, xcTestRunPath := range xcTestRunPaths {
+			log.Debugf("Reading test targets from: %s", xcTestRunPath)
+
+			targets, err := parseXCTestRun(xcTestRunPath)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, target := range targets {
+				if strings.HasSuffix(target.TestHostPath, "-Runner.app") {
+					runnerPaths = append(runnerPaths, target.TestHostPath)
+				} else if target.TestHostPath != "" {
+					appPaths = append(appPaths, target.TestHostPath)
+				}
+
+				if target.UITargetAppPath != "" {
+					appPaths = append(appPaths, target.UITargetAppPath)
+				}
+
+				for _, dependentProductPath := range target.DependentProductPaths {
+					if filepath.Ext(dependentProductPath) == ".app" && !strings.HasSuffix(dependentProductPath, "-Runner.app") {
+						appPaths = append(appPaths, dependentProductPath)
+					}
+				}
+			}
+		}
+	}
+
+	if len(runnerPaths) == 0 && len(appPaths) == 0 {
+		return nil, fmt.Errorf("no Test-Runner.app or test host app found in %s", buildPath)
+	}
+
+	var bundlePaths []string
+	added := map[string]bool{}
+	addBundle := func(pth string, requireProfile bool) error {
+		if added[pth] {
+			return nil
+		}
+
+		if exist, err := pathutil.IsDirExists(pth); err != nil {
+			return fmt.Errorf("failed to check if bundle exists at: %s, error: %s", pth, err)
+		} else if !exist {
+			log.Warnf("Bundle not found at: %s, skipping", pth)
+			return nil
+		}
+
+		if !requireProfile {
+			added[pth] = true
+			bundlePaths = append(bundlePaths, pth)
+			return nil
+		}
+
+		isMacOS, err := isMacOSBundle(pth)
+		if err != nil {
+			return err
+		}
+
+		profilePath := embeddedProfilePath(pth, isMacOS)
+		if exist, err := pathutil.IsPathExists(profilePath); err != nil {
+			return fmt.Errorf("failed to check if profile exists at: %s, error: %s", profilePath, err)
+		} else if !exist {
+			log.Debugf("No embedded provisioning profile in: %s, skipping", pth)
+			return nil
+		}
+
+		added[pth] = true
+		bundlePaths = append(bundlePaths, pth)
+		return nil
+	}
+
+	for _, runnerPath := range runnerPaths {
+		if err := addBundle(runnerPath, false); err != nil {
+			return nil, err
+		}
+	}
+	for _, appPath := range appPaths {
+		if err := addBundle(appPath, true); err != nil {
+			return nil, err
+		}
+	}
+
+	// App extensions and test bundles signed with their own provisioning profile
+	for _, bundlePath := range append([]string{}, bundlePaths...) {
+		isMacOS, err := isMacOSBundle(bundlePath)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pattern := range []string{"*.appex", "*.xctest"} {
+			nestedPaths, err := filepath.Glob(filepath.Join(pathutil.EscapeGlobPath(bundleContentsPath(bundlePath, isMacOS)), "PlugIns", pattern))
+			if err != nil {
+				return nil, err
+			}
+			sort.Strings(nestedPaths)
+
+			for _, nestedPath := range nestedPaths {
+				if err := addBundle(nestedPath, true); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return bundlePaths, nil
+}
@@ -0,0 +1,280 @@
+package codesigndocuitests
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const xcTestRunV1Content = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>SampleUITests</key>
+	<dict>
+		<key>IsUITestBundle</key>
+		<true/>
+		<key>TestBundlePath</key>
+		<string>__TESTHOST__/PlugIns/SampleUITests.xctest</string>
+		<key>TestHostPath</key>
+		<string>__TESTROOT__/Debug-iphoneos/SampleUITests-Runner.app</string>
+		<key>UITargetAppPath</key>
+		<string>__TESTROOT__/Debug-iphoneos/Sample.app</string>
+		<key>DependentProductPaths</key>
+		<array>
+			<string>__TESTROOT__/Debug-iphoneos/Sample.app</string>
+			<string>__TESTROOT__/Debug-iphoneos/SampleUITests-Runner.app</string>
+			<string>__TESTROOT__/Debug-iphoneos/SampleUITests-Runner.app/PlugIns/SampleUITests.xctest</string>
+		</array>
+	</dict>
+	<key>__xctestrun_metadata__</key>
+	<dict>
+		<key>FormatVersion</key>
+		<integer>1</integer>
+	</dict>
+</dict>
+</plist>
+`
+
+const xcTestRunV2Content = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>TestConfigurations</key>
+	<array>
+		<dict>
+			<key>Name</key>
+			<string>English</string>
+			<key>TestTargets</key>
+			<array>
+				<dict>
+					<key>BlueprintName</key>
+					<string>SampleUITests</string>
+					<key>IsUITestBundle</key>
+					<true/>
+					<key>TestBundlePath</key>
+					<string>__TESTHOST__/PlugIns/SampleUITests.xctest</string>
+					<key>TestHostPath</key>
+					<string>__TESTROOT__/Debug-iphoneos/SampleUITests-Runner.app</string>
+					<key>UITargetAppPath</key>
+					<string>__TESTROOT__/Debug-iphoneos/Sample.app</string>
+				</dict>
+			</array>
+		</dict>
+		<dict>
+			<key>Name</key>
+			<string>German</string>
+			<key>TestTargets</key>
+			<array>
+				<dict>
+					<key>BlueprintName</key>
+					<string>SampleTests</string>
+					<key>TestBundlePath</key>
+					<string>__TESTHOST__/PlugIns/SampleTests.xctest</string>
+					<key>TestHostPath</key>
+					<string>__TESTROOT__/Debug-iphoneos/Sample.app</string>
+				</dict>
+			</array>
+		</dict>
+	</array>
+	<key>__xctestrun_metadata__</key>
+	<dict>
+		<key>FormatVersion</key>
+		<integer>2</integer>
+	</dict>
+</dict>
+</plist>
+`
+
+const xcTestRunUnitTestsContent = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>TestConfigurations</key>
+	<array>
+		<dict>
+			<key>Name</key>
+			<string>Test Scheme Action</string>
+			<key>TestTargets</key>
+			<array>
+				<dict>
+					<key>BlueprintName</key>
+					<string>SampleTests</string>
+					<key>TestBundlePath</key>
+					<string>__TESTHOST__/PlugIns/SampleTests.xctest</string>
+					<key>TestHostPath</key>
+					<string>__TESTROOT__/Debug-iphoneos/Sample.app</string>
+					<key>DependentProductPaths</key>
+					<array>
+						<string>__TESTROOT__/Debug-iphoneos/Sample.app</string>
+						<string>__TESTROOT__/Debug-iphoneos/Sample.app/PlugIns/SampleTests.xctest</string>
+					</array>
+				</dict>
+			</array>
+		</dict>
+	</array>
+	<key>__xctestrun_metadata__</key>
+	<dict>
+		<key>FormatVersion</key>
+		<integer>2</integer>
+	</dict>
+</dict>
+</plist>
+`
+
+func writeFile(t *testing.T, pth, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(pth), 0755))
+	require.NoError(t, ioutil.WriteFile(pth, []byte(content), 0644))
+}
+
+func TestParseXCTestRun(t *testing.T) {
+	testRoot, err := ioutil.TempDir("", "xctestrun")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(testRoot))
+	}()
+
+	runnerPath := filepath.Join(testRoot, "Debug-iphoneos", "SampleUITests-Runner.app")
+	appPath := filepath.Join(testRoot, "Debug-iphoneos", "Sample.app")
+
+	t.Run("format version 1", func(t *testing.T) {
+		pth := filepath.Join(testRoot, "Sample_iphoneos-arm64.xctestrun")
+		writeFile(t, pth, xcTestRunV1Content)
+
+		targets, err := parseXCTestRun(pth)
+		require.NoError(t, err)
+		require.Equal(t, []xcTestRunTarget{
+			{
+				Name:            "SampleUITests",
+				TestHostPath:    runnerPath,
+				TestBundlePath:  filepath.Join(runnerPath, "PlugIns", "SampleUITests.xctest"),
+				UITargetAppPath: appPath,
+				DependentProductPaths: []string{
+					appPath,
+					runnerPath,
+					filepath.Join(runnerPath, "PlugIns", "SampleUITests.xctest"),
+				},
+				IsUITestBundle: true,
+			},
+		}, targets)
+	})
+
+	t.Run("format version 2", func(t *testing.T) {
+		pth := filepath.Join(testRoot, "Sample_TestPlan_iphoneos-arm64.xctestrun")
+		writeFile(t, pth, xcTestRunV2Content)
+
+		targets, err := parseXCTestRun(pth)
+		require.NoError(t, err)
+		require.Equal(t, []xcTestRunTarget{
+			{
+				Name:            "SampleUITests",
+				TestHostPath:    runnerPath,
+				TestBundlePath:  filepath.Join(runnerPath, "PlugIns", "SampleUITests.xctest"),
+				UITargetAppPath: appPath,
+				IsUITestBundle:  true,
+			},
+			{
+				Name:           "SampleTests",
+				TestHostPath:   appPath,
+				TestBundlePath: filepath.Join(appPath, "PlugIns", "SampleTests.xctest"),
+			},
+		}, targets)
+	})
+}
+
+func TestSignedBundlePaths(t *testing.T) {
+	t.Run("collects every signed bundle from the xctestrun files", func(t *testing.T) {
+		buildPath, err := ioutil.TempDir("", "build-for-testing")
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, os.RemoveAll(buildPath))
+		}()
+
+		productsPath := filepath.Join(buildPath, "Debug-iphoneos")
+		runnerPath := filepath.Join(productsPath, "SampleUITests-Runner.app")
+		appPath := filepath.Join(productsPath, "Sample.app")
+
+		writeFile(t, filepath.Join(buildPath, "Sample_iphoneos-arm64.xctestrun"), xcTestRunV1Content)
+		writeFile(t, filepath.Join(buildPath, "Sample_TestPlan_iphoneos-arm64.xctestrun"), xcTestRunV2Content)
+		writeFile(t, filepath.Join(runnerPath, "embedded.mobileprovision"), "")
+		writeFile(t, filepath.Join(runnerPath, "PlugIns", "SampleUITests.xctest", "Info.plist"), "")
+		writeFile(t, filepath.Join(appPath, "embedded.mobileprovision"), "")
+		writeFile(t, filepath.Join(appPath, "PlugIns", "Widget.appex", "embedded.mobileprovision"), "")
+		writeFile(t, filepath.Join(appPath, "PlugIns", "SampleTests.xctest", "Info.plist"), "")
+
+		paths, err := signedBundlePaths(buildPath)
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			runnerPath,
+			appPath,
+			filepath.Join(appPath, "PlugIns", "Widget.appex"),
+		}, paths)
+	})
+
+	t.Run("collects the test host of a unit test only test plan", func(t *testing.T) {
+		buildPath, err := ioutil.TempDir("", "build-for-testing")
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, os.RemoveAll(buildPath))
+		}()
+
+		appPath := filepath.Join(buildPath, "Debug-iphoneos", "Sample.app")
+		writeFile(t, filepath.Join(buildPath, "Sample_UnitTests_iphoneos-arm64.xctestrun"), xcTestRunUnitTestsContent)
+		writeFile(t, filepath.Join(appPath, "embedded.mobileprovision"), "")
+		writeFile(t, filepath.Join(appPath, "PlugIns", "SampleTests.xctest", "Info.plist"), "")
+
+		paths, err := signedBundlePaths(buildPath)
+		require.NoError(t, err)
+		require.Equal(t, []string{appPath}, paths)
+	})
+
+	t.Run("falls back to the *-Runner.app bundles without xctestrun file", func(t *testing.T) {
+		buildPath, err := ioutil.TempDir("", "build-for-testing")
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, os.RemoveAll(buildPath))
+		}()
+
+		runnerPath := filepath.Join(buildPath, "SampleUITests-Runner.app")
+		writeFile(t, filepath.Join(runnerPath, "embedded.mobileprovision"), "")
+
+		paths, err := signedBundlePaths(buildPath)
+		require.NoError(t, err)
+		require.Equal(t, []string{runnerPath}, paths)
+	})
+
+	t.Run("collects macOS bundles", func(t *testing.T) {
+		buildPath, err := ioutil.TempDir("", "build-for-testing")
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, os.RemoveAll(buildPath))
+		}()
+
+		runnerPath := filepath.Join(buildPath, "Debug", "SampleUITests-Runner.app")
+		writeFile(t, filepath.Join(runnerPath, "Contents", "Info.plist"), "")
+		writeFile(t, filepath.Join(runnerPath, "Contents", "embedded.provisionprofile"), "")
+		writeFile(t, filepath.Join(runnerPath, "Contents", "PlugIns", "SampleUITests.xctest", "Contents", "Info.plist"), "")
+		writeFile(t, filepath.Join(runnerPath, "Contents", "PlugIns", "SampleUITests.xctest", "Contents", "embedded.provisionprofile"), "")
+
+		paths, err := signedBundlePaths(buildPath)
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			runnerPath,
+			filepath.Join(runnerPath, "Contents", "PlugIns", "SampleUITests.xctest"),
+		}, paths)
+	})
+
+	t.Run("fails without test runner", func(t *testing.T) {
+		buildPath, err := ioutil.TempDir("", "build-for-testing")
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, os.RemoveAll(buildPath))
+		}()
+
+		_, err = signedBundlePaths(buildPath)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,51 @@
+package crossplatform
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// flutterDefaultScheme is the scheme of a Flutter app without flavors.
+const flutterDefaultScheme = "Runner"
+
+// FlutterFlavors returns the flavors of the Flutter project: the shared schemes of the Runner project, except the Runner scheme.
+func FlutterFlavors(project Project) ([]string, error) {
+	schemesDir := filepath.Join(project.RootDir, string(project.Platform), flutterDefaultScheme+".xcodeproj", "xcshareddata", "xcschemes")
+	schemePaths, err := filepath.Glob(filepath.Join(schemesDir, "*.xcscheme"))
+	if err != nil {
+		return nil, err
+	}
+
+	var flavors []string
+	for _, pth := range schemePaths {
+		scheme := strings.TrimSuffix(filepath.Base(pth), ".xcscheme")
+		if scheme == flutterDefaultScheme {
+			continue
+		}
+		flavors = append(flavors, scheme)
+	}
+	sort.Strings(flavors)
+	return flavors, nil
+}
+
+// FlutterFlavorSchemeAndConfiguration returns the scheme and the build configuration `flutter build --flavor` archives with:
+// the scheme named as the flavor (matched case-insensitively) and the Release-<scheme> configuration.
+// Without a flavor it is the Runner scheme and its archive action's configuration (returned empty).
+func FlutterFlavorSchemeAndConfiguration(flavors []string, flavor string) (string, string, error) {
+	if flavor == "" {
+		return flutterDefaultScheme, "", nil
+	}
+
+	for _, scheme := range flavors {
+		if strings.EqualFold(scheme, flavor) {
+			return scheme, "Release-" + scheme, nil
+		}
+	}
+
+	if len(flavors) == 0 {
+		return "", "", fmt.Errorf("flavor (%s) not found, the project has no flavors (shared schemes other than %s)", flavor, flutterDefaultScheme)
+	}
+	return "", "", fmt.Errorf("flavor (%s) not found, available flavors: %s", flavor, strings.Join(flavors, ", "))
+}
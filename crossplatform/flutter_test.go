@@ -0,0 +1,43 @@
+package crossplatform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlutterFlavors(t *testing.T) {
+	dir := tempDir(t)
+	createFiles(t, dir,
+		"pubspec.yaml",
+		"ios/Runner.xcworkspace",
+		"ios/Runner.xcodeproj/xcshareddata/xcschemes/Runner.xcscheme",
+		"ios/Runner.xcodeproj/xcshareddata/xcschemes/prod.xcscheme",
+		"ios/Runner.xcodeproj/xcshareddata/xcschemes/dev.xcscheme",
+	)
+
+	project, err := FindProject(Flutter, dir, IOS)
+	require.NoError(t, err)
+
+	flavors, err := FlutterFlavors(project)
+	require.NoError(t, err)
+	require.Equal(t, []string{"dev", "prod"}, flavors)
+}
+
+func TestFlutterFlavorSchemeAndConfiguration(t *testing.T) {
+	scheme, configuration, err := FlutterFlavorSchemeAndConfiguration(nil, "")
+	require.NoError(t, err)
+	require.Equal(t, "Runner", scheme)
+	require.Equal(t, "", configuration)
+
+	scheme, configuration, err = FlutterFlavorSchemeAndConfiguration([]string{"dev", "prod"}, "Prod")
+	require.NoError(t, err)
+	require.Equal(t, "prod", scheme)
+	require.Equal(t, "Release-prod", configuration)
+
+	_, _, err = FlutterFlavorSchemeAndConfiguration([]string{"dev", "prod"}, "staging")
+	require.EqualError(t, err, "flavor (staging) not found, available flavors: dev, prod")
+
+	_, _, err = FlutterFlavorSchemeAndConfiguration(nil, "prod")
+	require.Error(t, err)
+}
@@ -0,0 +1,203 @@
+package crossplatform
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Framework is a cross-platform framework with a native Xcode project.
+type Framework string
+
+// Frameworks ...
+const (
+	Flutter     Framework = "Flutter"
+	ReactNative Framework = "React Native"
+	Capacitor   Framework = "Capacitor"
+)
+
+// Platform is the platform of the native project, named as the directory it is in.
+type Platform string
+
+// Platforms ...
+const (
+	IOS   Platform = "ios"
+	MacOS Platform = "macos"
+)
+
+// ParsePlatform returns the platform for the given name.
+func ParsePlatform(name string) (Platform, error) {
+	switch Platform(strings.ToLower(strings.TrimSpace(name))) {
+	case IOS:
+		return IOS, nil
+	case MacOS:
+		return MacOS, nil
+	}
+	return "", fmt.Errorf("invalid platform (%s), valid values: %s, %s", name, IOS, MacOS)
+}
+
+// Project is the native Xcode project of a cross-platform project.
+type Project struct {
+	Framework Framework
+	Platform  Platform
+	// RootDir is the root directory of the cross-platform project.
+	RootDir string
+	// Path is the Xcode workspace, or the Xcode project if the workspace is missing.
+	Path string
+}
+
+// NativeDir returns the directory of the Xcode workspace or project, where the Podfile is.
+func (p Project) NativeDir() string {
+	return filepath.Dir(p.Path)
+}
+
+// FindProject returns the native Xcode project of the cross-platform project in its conventional place:
+// - Flutter: ios/Runner.xcworkspace or macos/Runner.xcworkspace
+// - React Native: the workspace in the ios or macos directory
+// - Capacitor: ios/App/App.xcworkspace
+// The Xcode project is returned if the workspace is missing, i.e. the CocoaPods dependencies are not installed yet.
+func FindProject(framework Framework, rootDir string, platform Platform) (Project, error) {
+	if err := checkFrameworkManifest(framework, rootDir); err != nil {
+		return Project{}, err
+	}
+
+	var pth string
+	var err error
+	switch framework {
+	case Flutter:
+		pth, err = findNativeProject(filepath.Join(rootDir, string(platform)), "Runner")
+	case ReactNative:
+		pth, err = findNativeProject(filepath.Join(rootDir, string(platform)), "")
+	case Capacitor:
+		if platform != IOS {
+			return Project{}, fmt.Errorf("%s supports the %s platform only", framework, IOS)
+		}
+		pth, err = findNativeProject(filepath.Join(rootDir, "ios", "App"), "App")
+	default:
+		return Project{}, fmt.Errorf("unknown framework: %s", framework)
+	}
+	if err != nil {
+		return Project{}, err
+	}
+
+	return Project{
+		Framework: framework,
+		Platform:  platform,
+		RootDir:   rootDir,
+		Path:      pth,
+	}, nil
+}
+
+// checkFrameworkManifest checks if the root directory includes the manifest of the framework.
+func checkFrameworkManifest(framework Framework, rootDir string) error {
+	var manifests []string
+	switch framework {
+	case Flutter:
+		manifests = []string{"pubspec.yaml"}
+	case ReactNative:
+		manifests = []string{"package.json"}
+	case Capacitor:
+		manifests = []string{"capacitor.config.json", "capacitor.config.ts", "capacitor.config.js"}
+	}
+
+	for _, manifest := range manifests {
+		if exists(filepath.Join(rootDir, manifest)) {
+			return nil
+		}
+	}
+	return fmt.Errorf("no %s found in %s, is it a %s project?", strings.Join(manifests, " or "), rootDir, framework)
+}
+
+// findNativeProject returns the workspace, or if missing, the project with the given name in the directory.
+// If no name is given, the only workspace or project of the directory is returned.
+func findNativeProject(dir, name string) (string, error) {
+	for _, ext := range []string{".xcworkspace", ".xcodeproj"} {
+		if name != "" {
+			pth := filepath.Join(dir, name+ext)
+			if exists(pth) {
+				return pth, nil
+			}
+			continue
+		}
+
+		matches, err := filepath.Glob(filepath.Join(dir, "*"+ext))
+		if err != nil {
+			return "", err
+		}
+		sort.Strings(matches)
+		switch len(matches) {
+		case 0:
+			continue
+		case 1:
+			return matches[0], nil
+		default:
+			return "", fmt.Errorf("multiple %s files found in %s, use the scan xcode command with the --file flag instead: %s", ext, dir, strings.Join(matches, ", "))
+		}
+	}
+
+	if name != "" {
+		return "", fmt.Errorf("no %s.xcworkspace or %s.xcodeproj found in %s", name, name, dir)
+	}
+	return "", fmt.Errorf("no Xcode workspace or project found in %s", dir)
+}
+
+// MissingPrerequisites returns a warning for each missing prerequisite of archiving the native project:
+// the CocoaPods dependencies, the Flutter build configuration, the JavaScript dependencies and the Capacitor web assets.
+func (p Project) MissingPrerequisites() []string {
+	var warnings []string
+
+	switch p.Framework {
+	case Flutter:
+		generatedConfig := filepath.Join(p.RootDir, "ios", "Flutter", "Generated.xcconfig")
+		if p.Platform == MacOS {
+			generatedConfig = filepath.Join(p.RootDir, "macos", "Flutter", "ephemeral", "Flutter-Generated.xcconfig")
+		}
+		if !exists(generatedConfig) {
+			warnings = append(warnings, fmt.Sprintf("%s is missing, run `flutter build %s --config-only` (or `flutter pub get`) in %s", generatedConfig, p.Platform, p.RootDir))
+		}
+	case ReactNative, Capacitor:
+		if !exists(filepath.Join(p.RootDir, "node_modules")) {
+			warnings = append(warnings, fmt.Sprintf("the JavaScript dependencies are not installed, run `npm install` or `yarn install` in %s", p.RootDir))
+		}
+	}
+
+	if p.Framework == Capacitor && !exists(filepath.Join(p.NativeDir(), "App", "public")) {
+		warnings = append(warnings, fmt.Sprintf("the web assets are not copied into the native project, build the web app and run `npx cap sync %s` in %s", p.Platform, p.RootDir))
+	}
+
+	if warning := missingPods(p.NativeDir()); warning != "" {
+		warnings = append(warnings, warning)
+	}
+
+	return warnings
+}
+
+// missingPods returns a warning if the directory has a Podfile, but its dependencies are not installed or out of date.
+func missingPods(dir string) string {
+	if !exists(filepath.Join(dir, "Podfile")) {
+		return ""
+	}
+
+	podfileLock, err := ioutil.ReadFile(filepath.Join(dir, "Podfile.lock"))
+	if err != nil {
+		return fmt.Sprintf("the CocoaPods dependencies are not installed, run `pod install` in %s", dir)
+	}
+
+	manifestLock, err := ioutil.ReadFile(filepath.Join(dir, "Pods", "Manifest.lock"))
+	if err != nil {
+		return fmt.Sprintf("the CocoaPods dependencies are not installed, run `pod install` in %s", dir)
+	}
+
+	if string(podfileLock) != string(manifestLock) {
+		return fmt.Sprintf("the installed CocoaPods dependencies are out of date (Podfile.lock and Pods/Manifest.lock differ), run `pod install` in %s", dir)
+	}
+	return ""
+}
+
+func exists(pth string) bool {
+	_, err := os.Stat(pth)
+	return err == nil
+}
@@ -0,0 +1,151 @@
+package crossplatform
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// createFiles creates the files and directories (ending with "/") in the directory.
+func createFiles(t *testing.T, dir string, paths ...string) {
+	for _, rel := range paths {
+		pth := filepath.Join(dir, rel)
+		if strings.HasSuffix(rel, "/") || filepath.Ext(pth) == ".xcworkspace" || filepath.Ext(pth) == ".xcodeproj" {
+			require.NoError(t, os.MkdirAll(pth, 0700))
+			continue
+		}
+		require.NoError(t, os.MkdirAll(filepath.Dir(pth), 0700))
+		require.NoError(t, ioutil.WriteFile(pth, []byte(filepath.Base(pth)), 0600))
+	}
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "crossplatform")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, os.RemoveAll(dir))
+	})
+	return dir
+}
+
+func TestFindProject(t *testing.T) {
+	tests := []struct {
+		name      string
+		framework Framework
+		platform  Platform
+		files     []string
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:      "Flutter workspace",
+			framework: Flutter,
+			platform:  IOS,
+			files:     []string{"pubspec.yaml", "ios/Runner.xcodeproj", "ios/Runner.xcworkspace"},
+			want:      "ios/Runner.xcworkspace",
+		},
+		{
+			name:      "Flutter macOS project without workspace",
+			framework: Flutter,
+			platform:  MacOS,
+			files:     []string{"pubspec.yaml", "ios/Runner.xcworkspace", "macos/Runner.xcodeproj"},
+			want:      "macos/Runner.xcodeproj",
+		},
+		{
+			name:      "Flutter without pubspec.yaml",
+			framework: Flutter,
+			platform:  IOS,
+			files:     []string{"ios/Runner.xcworkspace"},
+			wantErr:   true,
+		},
+		{
+			name:      "React Native workspace",
+			framework: ReactNative,
+			platform:  IOS,
+			files:     []string{"package.json", "ios/AwesomeApp.xcodeproj", "ios/AwesomeApp.xcworkspace"},
+			want:      "ios/AwesomeApp.xcworkspace",
+		},
+		{
+			name:      "React Native with multiple workspaces",
+			framework: ReactNative,
+			platform:  IOS,
+			files:     []string{"package.json", "ios/AwesomeApp.xcworkspace", "ios/Other.xcworkspace"},
+			wantErr:   true,
+		},
+		{
+			name:      "Capacitor workspace",
+			framework: Capacitor,
+			platform:  IOS,
+			files:     []string{"capacitor.config.ts", "ios/App/App.xcodeproj", "ios/App/App.xcworkspace"},
+			want:      "ios/App/App.xcworkspace",
+		},
+		{
+			name:      "Capacitor macOS",
+			framework: Capacitor,
+			platform:  MacOS,
+			files:     []string{"capacitor.config.json", "ios/App/App.xcworkspace"},
+			wantErr:   true,
+		},
+		{
+			name:      "no native project",
+			framework: Capacitor,
+			platform:  IOS,
+			files:     []string{"capacitor.config.json"},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := tempDir(t)
+			createFiles(t, dir, tt.files...)
+
+			project, err := FindProject(tt.framework, dir, tt.platform)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, filepath.Join(dir, tt.want), project.Path)
+		})
+	}
+}
+
+func TestMissingPrerequisites(t *testing.T) {
+	dir := tempDir(t)
+	createFiles(t, dir, "pubspec.yaml", "ios/Runner.xcworkspace", "ios/Podfile")
+
+	project, err := FindProject(Flutter, dir, IOS)
+	require.NoError(t, err)
+	require.Len(t, project.MissingPrerequisites(), 2)
+
+	createFiles(t, dir, "ios/Flutter/Generated.xcconfig", "ios/Podfile.lock")
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "ios", "Podfile.lock"), []byte("PODS: []"), 0600))
+	warnings := project.MissingPrerequisites()
+	require.Len(t, warnings, 1)
+	require.Contains(t, warnings[0], "pod install")
+
+	createFiles(t, dir, "ios/Pods/")
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "ios", "Pods", "Manifest.lock"), []byte("PODS: [Alamofire]"), 0600))
+	warnings = project.MissingPrerequisites()
+	require.Len(t, warnings, 1)
+	require.Contains(t, warnings[0], "out of date")
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "ios", "Pods", "Manifest.lock"), []byte("PODS: []"), 0600))
+	require.Empty(t, project.MissingPrerequisites())
+}
+
+func TestMissingPrerequisitesCapacitor(t *testing.T) {
+	dir := tempDir(t)
+	createFiles(t, dir, "capacitor.config.json", "ios/App/App.xcworkspace")
+
+	project, err := FindProject(Capacitor, dir, IOS)
+	require.NoError(t, err)
+	require.Len(t, project.MissingPrerequisites(), 2)
+
+	createFiles(t, dir, "node_modules/", "ios/App/App/public/")
+	require.Empty(t, project.MissingPrerequisites())
+}
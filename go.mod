@@ -7,7 +7,11 @@ require (
 	github.com/bitrise-io/go-utils v1.0.1
 	github.com/bitrise-io/go-xcode v1.0.3
 	github.com/bitrise-io/goinp v0.0.0-20210504152833-8559b0680ab1
+	github.com/bitrise-io/pkcs12 v0.0.0-20211108084543-e52728e011c8
+	github.com/fullsailor/pkcs7 v0.0.0-20190404230743-d7302db945fa
 	github.com/pkg/errors v0.9.1
 	github.com/spf13/cobra v1.1.3
 	github.com/stretchr/testify v1.7.0
+	golang.org/x/term v0.0.0-20210927222741-03fcf44c2211
+	gopkg.in/yaml.v2 v2.4.0
 )
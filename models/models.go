@@ -11,6 +11,14 @@ type Certificates struct {
 	Content []byte
 }
 
+// Identities contains an identities (.p12) file, its passphrase and the infos of its certificates.
+type Identities struct {
+	FileName string
+	Password string
+	Info     []certificateutil.CertificateInfoModel
+	Content  []byte
+}
+
 // ProvisioningProfile contains parsed data in the provisioning profile and the original profile file contents.
 type ProvisioningProfile struct {
 	Info    profileutil.ProvisioningProfileInfoModel
@@ -75,6 +75,119 @@ func ExportFromKeychain(itemRefsToExport []C.CFTypeRef, isAskForPassword bool) (
 	return dataBytes, nil
 }
 
+// ErrDuplicateItem is returned by ImportToKeychain if the imported items are already in the keychain.
+var ErrDuplicateItem = errors.New("the items already exist in the keychain")
+
+// ImportToKeychain imports the identities of the given PKCS12 content into the default keychain.
+// The passphrase is passed to SecItemImport in-process, the imported private keys can be used
+// by the given applications (i.e. /usr/bin/codesign) without a prompt.
+func ImportToKeychain(pkcs12Content []byte, passphrase string, trustedApplicationPaths []string) error {
+	if len(pkcs12Content) == 0 {
+		return errors.New("ImportToKeychain: nothing to import - empty content")
+	}
+
+	var keychain C.SecKeychainRef
+	status := C.SecKeychainCopyDefault(&keychain)
+	if status != C.errSecSuccess {
+		return fmt.Errorf("SecKeychainCopyDefault: error (OSStatus): %d", status)
+	}
+	defer C.CFRelease(C.CFTypeRef(keychain))
+
+	importedData := C.CFDataCreate(C.kCFAllocatorDefault, (*C.UInt8)(unsafe.Pointer(&pkcs12Content[0])), C.CFIndex(len(pkcs12Content)))
+	defer C.CFRelease(C.CFTypeRef(importedData))
+
+	passphraseCString := C.CString(passphrase)
+	defer C.free(unsafe.Pointer(passphraseCString))
+	passphraseCFString := convertCStringToCFString(passphraseCString)
+	defer C.CFRelease(C.CFTypeRef(passphraseCFString))
+
+	access, err := createAccess(trustedApplicationPaths)
+	if err != nil {
+		return err
+	}
+	defer C.CFRelease(C.CFTypeRef(access))
+
+	var importParams C.SecItemImportExportKeyParameters
+	importParams.version = C.SEC_KEY_IMPORT_EXPORT_PARAMS_VERSION
+	importParams.flags = 0
+	importParams.passphrase = C.CFTypeRef(passphraseCFString)
+	importParams.alertTitle = 0
+	importParams.alertPrompt = 0
+	importParams.accessRef = access
+	importParams.keyUsage = 0
+	importParams.keyAttributes = 0
+
+	inputFormat := C.SecExternalFormat(C.kSecFormatPKCS12)
+	itemType := C.SecExternalItemType(C.kSecItemTypeAggregate)
+
+	// do the import!
+	status = C.SecItemImport(importedData,
+		0,
+		&inputFormat,
+		&itemType,
+		0,
+		&importParams,
+		keychain,
+		nil)
+
+	if status == C.errSecDuplicateItem {
+		return ErrDuplicateItem
+	}
+	if status != C.errSecSuccess {
+		return fmt.Errorf("SecItemImport: error (OSStatus): %d", status)
+	}
+	log.Debugf("Import - success")
+
+	return nil
+}
+
+// createAccess returns an access for the imported private keys, allowing the given applications to use them.
+// The returned access has to be released with C.CFRelease.
+func createAccess(trustedApplicationPaths []string) (C.SecAccessRef, error) {
+	var trustedApplications []C.CFTypeRef
+	defer func() {
+		for _, application := range trustedApplications {
+			C.CFRelease(application)
+		}
+	}()
+
+	for _, pth := range trustedApplicationPaths {
+		pathCString := C.CString(pth)
+		defer C.free(unsafe.Pointer(pathCString))
+
+		var application C.SecTrustedApplicationRef
+		status := C.SecTrustedApplicationCreateFromPath(pathCString, &application)
+		if status != C.errSecSuccess {
+			return 0, fmt.Errorf("SecTrustedApplicationCreateFromPath (%s): error (OSStatus): %d", pth, status)
+		}
+		trustedApplications = append(trustedApplications, C.CFTypeRef(application))
+	}
+
+	var ptr *unsafe.Pointer
+	if len(trustedApplications) > 0 {
+		ptr = (*unsafe.Pointer)(unsafe.Pointer(&trustedApplications[0]))
+	}
+	trustedList := C.CFArrayCreate(
+		C.kCFAllocatorDefault,
+		ptr,
+		C.CFIndex(len(trustedApplications)),
+		&C.kCFTypeArrayCallBacks)
+	defer C.CFRelease(C.CFTypeRef(trustedList))
+
+	descriptorCString := C.CString("Imported Private Key")
+	defer C.free(unsafe.Pointer(descriptorCString))
+	descriptor := convertCStringToCFString(descriptorCString)
+	defer C.CFRelease(C.CFTypeRef(descriptor))
+
+	var access C.SecAccessRef
+	status := C.SecAccessCreate(descriptor, trustedList, &access)
+	if status != C.errSecSuccess {
+		return 0, fmt.Errorf("SecAccessCreate: error (OSStatus): %d", status)
+	}
+
+	return access, nil
+}
+
 func convertCFDataRefToGoBytes(cfdata C.CFDataRef) []byte {
 	return C.GoBytes(unsafe.Pointer(C.CFDataGetBytePtr(cfdata)), (C.int)(C.CFDataGetLength(cfdata)))
 }
@@ -0,0 +1,82 @@
+package projectmanifest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/bitrise-io/go-utils/command"
+)
+
+// Kind is the project generator of a manifest.
+type Kind string
+
+// Kinds ...
+const (
+	XcodeGen Kind = "XcodeGen"
+	Tuist    Kind = "Tuist"
+)
+
+// manifestFileNames are the file names of the manifests by project generator, in order of precedence.
+var manifestFileNames = []struct {
+	kind     Kind
+	fileName string
+}{
+	{XcodeGen, "project.yml"},
+	{XcodeGen, "project.yaml"},
+	{Tuist, "Workspace.swift"},
+	{Tuist, "Project.swift"},
+}
+
+// Manifest is a project manifest the Xcode project is generated from.
+type Manifest struct {
+	Kind Kind
+	Path string
+}
+
+// IsXcodeGenManifest returns true if the file name is the name of an XcodeGen manifest.
+func IsXcodeGenManifest(pth string) bool {
+	for _, manifest := range manifestFileNames {
+		if manifest.kind == XcodeGen && manifest.fileName == filepath.Base(pth) {
+			return true
+		}
+	}
+	return false
+}
+
+// Find returns the XcodeGen (project.yml) or Tuist (Workspace.swift, Project.swift) manifest in the directory.
+// The returned bool is false if the directory has no manifest.
+func Find(dir string) (Manifest, bool) {
+	for _, manifest := range manifestFileNames {
+		pth := filepath.Join(dir, manifest.fileName)
+		if info, err := os.Stat(pth); err == nil && !info.IsDir() {
+			return Manifest{Kind: manifest.kind, Path: pth}, true
+		}
+	}
+	return Manifest{}, false
+}
+
+// GenerateCommand returns the command generating the Xcode project from the manifest.
+func (manifest Manifest) GenerateCommand() (*command.Model, error) {
+	switch manifest.Kind {
+	case XcodeGen:
+		return command.New("xcodegen", "generate", "--spec", manifest.Path).SetDir(filepath.Dir(manifest.Path)), nil
+	case Tuist:
+		return command.New("tuist", "generate", "--no-open").SetDir(filepath.Dir(manifest.Path)), nil
+	}
+	return nil, fmt.Errorf("unknown project manifest: %s", manifest.Path)
+}
+
+// Generate generates the Xcode project from the manifest.
+func (manifest Manifest) Generate() error {
+	cmd, err := manifest.GenerateCommand()
+	if err != nil {
+		return err
+	}
+
+	out, err := cmd.RunAndReturnTrimmedCombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to generate the Xcode project (%s): %s, error: %s", cmd.PrintableCommandArgs(), out, err)
+	}
+	return nil
+}
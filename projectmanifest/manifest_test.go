@@ -0,0 +1,43 @@
+package projectmanifest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFind(t *testing.T) {
+	dir, err := ioutil.TempDir("", "projectmanifest")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(dir))
+	}()
+
+	_, found := Find(dir)
+	require.False(t, found)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "Project.swift"), []byte("import ProjectDescription"), 0600))
+	manifest, found := Find(dir)
+	require.True(t, found)
+	require.Equal(t, Manifest{Kind: Tuist, Path: filepath.Join(dir, "Project.swift")}, manifest)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "project.yml"), []byte("name: MyApp"), 0600))
+	manifest, found = Find(dir)
+	require.True(t, found)
+	require.Equal(t, Manifest{Kind: XcodeGen, Path: filepath.Join(dir, "project.yml")}, manifest)
+
+	cmd, err := manifest.GenerateCommand()
+	require.NoError(t, err)
+	require.Equal(t, []string{"xcodegen", "generate", "--spec", manifest.Path}, cmd.GetCmd().Args)
+	require.Equal(t, dir, cmd.GetCmd().Dir)
+}
+
+func TestIsXcodeGenManifest(t *testing.T) {
+	require.True(t, IsXcodeGenManifest("/path/to/project.yml"))
+	require.True(t, IsXcodeGenManifest("project.yaml"))
+	require.False(t, IsXcodeGenManifest("Project.swift"))
+	require.False(t, IsXcodeGenManifest("MyApp.xcodeproj"))
+}
@@ -0,0 +1,202 @@
+package projectmanifest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Build settings and target attributes describing the code signing of a target.
+const (
+	bundleIDKey                     = "PRODUCT_BUNDLE_IDENTIFIER"
+	developmentTeamKey              = "DEVELOPMENT_TEAM"
+	codeSignStyleKey                = "CODE_SIGN_STYLE"
+	codeSignIdentityKey             = "CODE_SIGN_IDENTITY"
+	provisioningProfileSpecifierKey = "PROVISIONING_PROFILE_SPECIFIER"
+
+	developmentTeamAttribute   = "DevelopmentTeam"
+	provisioningStyleAttribute = "ProvisioningStyle"
+)
+
+// TargetSigning is the code signing settings of a target's build configuration.
+type TargetSigning struct {
+	Target        string
+	Type          string
+	Platform      string
+	Configuration string
+
+	BundleID                     string
+	TeamID                       string
+	SigningStyle                 string
+	CodeSignIdentity             string
+	ProvisioningProfileSpecifier string
+}
+
+type xcodeGenSpec struct {
+	Name    string `yaml:"name"`
+	Options struct {
+		BundleIDPrefix string `yaml:"bundleIdPrefix"`
+	} `yaml:"options"`
+	Configs  map[string]string         `yaml:"configs"`
+	Settings xcodeGenSettings          `yaml:"settings"`
+	Targets  map[string]xcodeGenTarget `yaml:"targets"`
+}
+
+type xcodeGenTarget struct {
+	Type       string                 `yaml:"type"`
+	Platform   interface{}            `yaml:"platform"`
+	Settings   xcodeGenSettings       `yaml:"settings"`
+	Attributes map[string]interface{} `yaml:"attributes"`
+}
+
+// xcodeGenSettings is a settings entry of an XcodeGen spec: either a map of build settings,
+// or a map of base and per configuration build settings (base, configs keys).
+type xcodeGenSettings map[string]interface{}
+
+// isStructured returns true if the settings are given by base, configs and groups keys.
+func (settings xcodeGenSettings) isStructured() bool {
+	for _, key := range []string{"base", "configs", "groups"} {
+		if _, ok := settings[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// forConfiguration returns the build settings of the configuration: the base settings,
+// overridden by the settings of the configs keys included in the configuration name (case-insensitively), then of the exact match.
+func (settings xcodeGenSettings) forConfiguration(configuration string) map[string]string {
+	resolved := map[string]string{}
+	if !settings.isStructured() {
+		mergeBuildSettings(resolved, settings)
+		return resolved
+	}
+
+	mergeBuildSettings(resolved, settings["base"])
+
+	configs, ok := settings["configs"].(map[interface{}]interface{})
+	if !ok {
+		return resolved
+	}
+	var names []string
+	for name := range configs {
+		names = append(names, fmt.Sprint(name))
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if name != configuration && strings.Contains(strings.ToLower(configuration), strings.ToLower(name)) {
+			mergeBuildSettings(resolved, configs[name])
+		}
+	}
+	if exact, ok := configs[configuration]; ok {
+		mergeBuildSettings(resolved, exact)
+	}
+	return resolved
+}
+
+func mergeBuildSettings(into map[string]string, settings interface{}) {
+	switch settings := settings.(type) {
+	case xcodeGenSettings:
+		for key, value := range settings {
+			into[key] = fmt.Sprint(value)
+		}
+	case map[interface{}]interface{}:
+		for key, value := range settings {
+			into[fmt.Sprint(key)] = fmt.Sprint(value)
+		}
+	}
+}
+
+// ReadXcodeGenSigning reads the code signing settings of the application and extension targets from an XcodeGen spec,
+// for each build configuration, without generating the project.
+// The target settings override the project settings, the DevelopmentTeam and ProvisioningStyle target attributes
+// are used if the build settings do not set the team and the signing style.
+// The included specs, setting groups, target templates and build setting variables are not resolved.
+func ReadXcodeGenSigning(pth string) ([]TargetSigning, error) {
+	content, err := ioutil.ReadFile(pth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read XcodeGen spec (%s), error: %s", pth, err)
+	}
+
+	var spec xcodeGenSpec
+	if err := yaml.Unmarshal(content, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse XcodeGen spec (%s), error: %s", pth, err)
+	}
+
+	configurations := []string{"Debug", "Release"}
+	if len(spec.Configs) > 0 {
+		configurations = nil
+		for configuration := range spec.Configs {
+			configurations = append(configurations, configuration)
+		}
+		sort.Strings(configurations)
+	}
+
+	var targetNames []string
+	for name, target := range spec.Targets {
+		if isSignedTargetType(target.Type) {
+			targetNames = append(targetNames, name)
+		}
+	}
+	sort.Strings(targetNames)
+
+	var signings []TargetSigning
+	for _, name := range targetNames {
+		target := spec.Targets[name]
+		for _, configuration := range configurations {
+			settings := spec.Settings.forConfiguration(configuration)
+			for key, value := range target.Settings.forConfiguration(configuration) {
+				settings[key] = value
+			}
+
+			signing := TargetSigning{
+				Target:                       name,
+				Type:                         target.Type,
+				Platform:                     platformDescription(target.Platform),
+				Configuration:                configuration,
+				BundleID:                     settings[bundleIDKey],
+				TeamID:                       settings[developmentTeamKey],
+				SigningStyle:                 settings[codeSignStyleKey],
+				CodeSignIdentity:             settings[codeSignIdentityKey],
+				ProvisioningProfileSpecifier: settings[provisioningProfileSpecifierKey],
+			}
+			if signing.BundleID == "" && spec.Options.BundleIDPrefix != "" {
+				// XcodeGen sets the bundle ID to <bundleIdPrefix>.<target name> by default
+				signing.BundleID = spec.Options.BundleIDPrefix + "." + name
+			}
+			if signing.TeamID == "" && target.Attributes[developmentTeamAttribute] != nil {
+				signing.TeamID = fmt.Sprint(target.Attributes[developmentTeamAttribute])
+			}
+			if signing.SigningStyle == "" && target.Attributes[provisioningStyleAttribute] != nil {
+				signing.SigningStyle = fmt.Sprint(target.Attributes[provisioningStyleAttribute])
+			}
+
+			signings = append(signings, signing)
+		}
+	}
+
+	return signings, nil
+}
+
+// isSignedTargetType returns true for the application and app extension target types, which are signed when archiving.
+func isSignedTargetType(targetType string) bool {
+	return strings.HasPrefix(targetType, "application") || strings.Contains(targetType, "extension")
+}
+
+func platformDescription(platform interface{}) string {
+	if platforms, ok := platform.([]interface{}); ok {
+		var names []string
+		for _, p := range platforms {
+			names = append(names, fmt.Sprint(p))
+		}
+		return strings.Join(names, ", ")
+	}
+	if platform == nil {
+		return ""
+	}
+	return fmt.Sprint(platform)
+}
@@ -0,0 +1,52 @@
+package projectmanifest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadXcodeGenSigning(t *testing.T) {
+	signings, err := ReadXcodeGenSigning("testdata/project.yml")
+	require.NoError(t, err)
+
+	myApp := func(configuration, identity, specifier string) TargetSigning {
+		return TargetSigning{
+			Target:                       "MyApp",
+			Type:                         "application",
+			Platform:                     "iOS",
+			Configuration:                configuration,
+			BundleID:                     "io.bitrise.myapp",
+			TeamID:                       "ABCD1234",
+			SigningStyle:                 "Manual",
+			CodeSignIdentity:             identity,
+			ProvisioningProfileSpecifier: specifier,
+		}
+	}
+	widget := func(configuration, identity string) TargetSigning {
+		return TargetSigning{
+			Target:           "Widget",
+			Type:             "app-extension",
+			Platform:         "iOS",
+			Configuration:    configuration,
+			BundleID:         "io.bitrise.Widget",
+			TeamID:           "EFGH5678",
+			SigningStyle:     "Automatic",
+			CodeSignIdentity: identity,
+		}
+	}
+
+	require.Equal(t, []TargetSigning{
+		myApp("Debug", "", "MyApp Development"),
+		myApp("Release", "Apple Distribution", "MyApp App Store"),
+		myApp("Staging Release", "Apple Distribution", "MyApp Ad Hoc"),
+		widget("Debug", ""),
+		widget("Release", "Apple Distribution"),
+		widget("Staging Release", "Apple Distribution"),
+	}, signings)
+}
+
+func TestReadXcodeGenSigningInvalidSpec(t *testing.T) {
+	_, err := ReadXcodeGenSigning("testdata/missing.yml")
+	require.Error(t, err)
+}
@@ -33,11 +33,12 @@ func ProfileExportFileNameNoPath(info profileutil.ProvisioningProfileInfoModel)
 // Platform ...
 type Platform string
 
+// Platforms ...
 const (
-	iOS     Platform = "iOS"
-	osX     Platform = "OS X"
-	tvOS    Platform = "tvOS"
-	watchOS Platform = "watchOS"
+	IOS     Platform = "iOS"
+	OSX     Platform = "OS X"
+	TvOS    Platform = "tvOS"
+	WatchOS Platform = "watchOS"
 )
 
 // TargetBuildSettingsProvider ...
@@ -113,13 +114,13 @@ func getPlatform(buildSettings serialized.Object) (Platform, error) {
 
 	switch {
 	case strings.HasPrefix(sdk, "iphoneos"):
-		return iOS, nil
+		return IOS, nil
 	case strings.HasPrefix(sdk, "macosx"):
-		return osX, nil
+		return OSX, nil
 	case strings.HasPrefix(sdk, "appletvos"):
-		return tvOS, nil
+		return TvOS, nil
 	case strings.HasPrefix(sdk, "watchos"):
-		return watchOS, nil
+		return WatchOS, nil
 	default:
 		return "", fmt.Errorf("unknown SDKROOT: %s", sdk)
 	}
@@ -46,6 +46,26 @@ type CommandModel struct {
 	//	tvOS
 	//	tvOS Simulator
 	Destination string
+
+	// Configuration will be passed to xcodebuild as the -configuration flag's value,
+	// overriding the build configuration of the scheme's archive action.
+	// Only passed to xcodebuild if not empty!
+	Configuration string
+
+	// XCConfig is the path of an xcconfig file, passed to xcodebuild as the -xcconfig flag's value.
+	// Only passed to xcodebuild if not empty!
+	XCConfig string
+
+	// BuildSettings are additional KEY=VALUE build settings passed to xcodebuild.
+	BuildSettings []string
+
+	// AllowProvisioningUpdates passes the -allowProvisioningUpdates flag to xcodebuild,
+	// allowing it to create and update provisioning profiles of automatically signed targets.
+	AllowProvisioningUpdates bool
+
+	// DerivedDataPath will be passed to xcodebuild as the -derivedDataPath flag's value.
+	// Only passed to xcodebuild if not empty!
+	DerivedDataPath string
 }
 
 // GenerateArchive : generates the archive for subsequent "Scan"
@@ -98,9 +118,27 @@ func (xccmd CommandModel) transformToXcodebuildParams(xcodebuildActionArgs ...st
 		baseArgs = append(baseArgs, "-destination", xccmd.Destination)
 	}
 
+	if xccmd.Configuration != "" {
+		baseArgs = append(baseArgs, "-configuration", xccmd.Configuration)
+	}
+
+	if xccmd.XCConfig != "" {
+		baseArgs = append(baseArgs, "-xcconfig", xccmd.XCConfig)
+	}
+
+	if xccmd.AllowProvisioningUpdates {
+		baseArgs = append(baseArgs, "-allowProvisioningUpdates")
+	}
+
+	if xccmd.DerivedDataPath != "" {
+		baseArgs = append(baseArgs, "-derivedDataPath", xccmd.DerivedDataPath)
+	}
+
 	if xccmd.CodeSignIdentity != "" {
 		baseArgs = append(baseArgs, `CODE_SIGN_IDENTITY=`+xccmd.CodeSignIdentity)
 	}
+
+	baseArgs = append(baseArgs, xccmd.BuildSettings...)
 	return append(baseArgs, xcodebuildActionArgs...), nil
 }
 
@@ -110,7 +148,10 @@ func (xccmd CommandModel) RunXcodebuildCommand(xcodebuildActionArgs ...string) (
 	if err != nil {
 		return "", err
 	}
+	return runXcodebuild(xcodeCmdParamsToRun)
+}
 
+func runXcodebuild(xcodeCmdParamsToRun []string) (string, error) {
 	log.Infof("$ xcodebuild %s", command.PrintableCommandArgs(true, xcodeCmdParamsToRun))
 	xcoutput, err := command.RunCommandAndReturnCombinedStdoutAndStderr("xcodebuild", xcodeCmdParamsToRun...)
 	if err != nil {
@@ -121,9 +162,16 @@ func (xccmd CommandModel) RunXcodebuildCommand(xcodebuildActionArgs ...string) (
 	return xcoutput, nil
 }
 
-// ScanSchemes ...
+// ScanSchemes lists the schemes of the project or workspace.
+// The build options (configuration, xcconfig, build settings, derived data path ...) are not passed to xcodebuild -list,
+// they apply to the archive and build actions only.
 func (xccmd CommandModel) ScanSchemes() ([]string, error) {
-	xcoutput, err := xccmd.RunXcodebuildCommand("-list")
+	xcodeCmdParamsToRun, err := xccmd.listSchemesParams()
+	if err != nil {
+		return []string{}, err
+	}
+
+	xcoutput, err := runXcodebuild(xcodeCmdParamsToRun)
 	if err != nil {
 		return []string{}, fmt.Errorf("error: %s | xcodebuild output: %s", err, xcoutput)
 	}
@@ -132,6 +180,14 @@ func (xccmd CommandModel) ScanSchemes() ([]string, error) {
 	return parsedSchemes, nil
 }
 
+func (xccmd CommandModel) listSchemesParams() ([]string, error) {
+	projParam, err := xccmd.xcodeProjectOrWorkspaceParam()
+	if err != nil {
+		return []string{}, err
+	}
+	return []string{projParam, xccmd.ProjectFilePath, "-list"}, nil
+}
+
 func parseSchemesFromXcodeOutput(xcodeOutput string) []string {
 	scanner := bufio.NewScanner(strings.NewReader(xcodeOutput))
 
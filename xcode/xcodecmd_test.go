@@ -23,3 +23,38 @@ func Test_parseSchemesFromXcodeOutput(t *testing.T) {
 	parsedSchemes := parseSchemesFromXcodeOutput(xcout)
 	require.Equal(t, []string{"SampleAppWithCocoapods"}, parsedSchemes)
 }
+
+func Test_transformToXcodebuildParams(t *testing.T) {
+	xccmd := CommandModel{
+		ProjectFilePath:          "ios-sample/ios-sample.xcworkspace",
+		Scheme:                   "ios-sample",
+		Destination:              "generic/platform=iOS",
+		Configuration:            "Staging",
+		XCConfig:                 "Configs/Staging.xcconfig",
+		BuildSettings:            []string{"SWIFT_ACTIVE_COMPILATION_CONDITIONS=STAGING", "ENABLE_BITCODE=NO"},
+		AllowProvisioningUpdates: true,
+		DerivedDataPath:          "/tmp/DerivedData",
+	}
+
+	params, err := xccmd.transformToXcodebuildParams("clean", "archive")
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"-workspace", "ios-sample/ios-sample.xcworkspace",
+		"-scheme", "ios-sample",
+		"-destination", "generic/platform=iOS",
+		"-configuration", "Staging",
+		"-xcconfig", "Configs/Staging.xcconfig",
+		"-allowProvisioningUpdates",
+		"-derivedDataPath", "/tmp/DerivedData",
+		"SWIFT_ACTIVE_COMPILATION_CONDITIONS=STAGING", "ENABLE_BITCODE=NO",
+		"clean", "archive",
+	}, params)
+
+	params, err = xccmd.listSchemesParams()
+	require.NoError(t, err)
+	require.Equal(t, []string{"-workspace", "ios-sample/ios-sample.xcworkspace", "-list"}, params)
+
+	params, err = CommandModel{ProjectFilePath: "ios-sample/ios-sample.xcodeproj"}.transformToXcodebuildParams("-list")
+	require.NoError(t, err)
+	require.Equal(t, []string{"-project", "ios-sample/ios-sample.xcodeproj", "-list"}, params)
+}
@@ -45,6 +45,26 @@ type CommandModel struct {
 	//	tvOS
 	//	tvOS Simulator
 	Destination string
+
+	// Configuration will be passed to xcodebuild as the -configuration flag's value,
+	// overriding the build configuration of the scheme's test action.
+	// Only passed to xcodebuild if not empty!
+	Configuration string
+
+	// XCConfig is the path of an xcconfig file, passed to xcodebuild as the -xcconfig flag's value.
+	// Only passed to xcodebuild if not empty!
+	XCConfig string
+
+	// BuildSettings are additional KEY=VALUE build settings passed to xcodebuild.
+	BuildSettings []string
+
+	// AllowProvisioningUpdates passes the -allowProvisioningUpdates flag to xcodebuild,
+	// allowing it to create and update provisioning profiles of automatically signed targets.
+	AllowProvisioningUpdates bool
+
+	// DerivedDataPath is the derived data directory of the build-for-testing command,
+	// a temporary directory is used if empty.
+	DerivedDataPath string
 }
 
 // RunBuildForTesting runs the build-for-testing xcode command.
@@ -56,10 +76,16 @@ func (xcuitestcmd CommandModel) RunBuildForTesting() (string, string, error) {
 	if err != nil {
 		return "", "", fmt.Errorf("failed to create temp dir for archives, error: %s", err)
 	}
-	tmpBuildPath := filepath.Join(tmpDir, xcuitestcmd.Scheme)
+	// The .xctestrun files (one per test plan) are only generated into the derived data's Build/Products directory,
+	// next to the per-configuration build directories.
+	derivedDataPath := xcuitestcmd.DerivedDataPath
+	if derivedDataPath == "" {
+		derivedDataPath = filepath.Join(tmpDir, xcuitestcmd.Scheme+"-DerivedData")
+	}
+	tmpBuildPath := filepath.Join(derivedDataPath, "Build", "Products")
 
 	progress.SimpleProgress(".", 1*time.Second, func() {
-		xcoutput, err = xcuitestcmd.RunXcodebuildCommand("clean", "build-for-testing", "CONFIGURATION_BUILD_DIR="+tmpBuildPath)
+		xcoutput, err = xcuitestcmd.RunXcodebuildCommand("clean", "build-for-testing", "-derivedDataPath", derivedDataPath)
 	})
 	fmt.Println()
 
@@ -97,6 +123,19 @@ func (xcuitestcmd CommandModel) transformToXcodebuildParams(xcodebuildActionArgs
 		baseArgs = append(baseArgs, "-destination", xcuitestcmd.Destination)
 	}
 
+	if xcuitestcmd.Configuration != "" {
+		baseArgs = append(baseArgs, "-configuration", xcuitestcmd.Configuration)
+	}
+
+	if xcuitestcmd.XCConfig != "" {
+		baseArgs = append(baseArgs, "-xcconfig", xcuitestcmd.XCConfig)
+	}
+
+	if xcuitestcmd.AllowProvisioningUpdates {
+		baseArgs = append(baseArgs, "-allowProvisioningUpdates")
+	}
+
+	baseArgs = append(baseArgs, xcuitestcmd.BuildSettings...)
 	return append(baseArgs, xcodebuildActionArgs...), nil
 }
 
@@ -0,0 +1,32 @@
+package xcodeuitest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_transformToXcodebuildParams(t *testing.T) {
+	xcuitestcmd := CommandModel{
+		ProjectFilePath:          "ios-sample/ios-sample.xcworkspace",
+		Scheme:                   "ios-sample",
+		Destination:              "generic/platform=iOS",
+		Configuration:            "Staging",
+		XCConfig:                 "Configs/Staging.xcconfig",
+		BuildSettings:            []string{"ENABLE_BITCODE=NO"},
+		AllowProvisioningUpdates: true,
+	}
+
+	params, err := xcuitestcmd.transformToXcodebuildParams("clean", "build-for-testing", "-derivedDataPath", "/tmp/DerivedData")
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"-workspace", "ios-sample/ios-sample.xcworkspace",
+		"-scheme", "ios-sample",
+		"-destination", "generic/platform=iOS",
+		"-configuration", "Staging",
+		"-xcconfig", "Configs/Staging.xcconfig",
+		"-allowProvisioningUpdates",
+		"ENABLE_BITCODE=NO",
+		"clean", "build-for-testing", "-derivedDataPath", "/tmp/DerivedData",
+	}, params)
+}